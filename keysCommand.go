@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	helpers "github.com/Nooksd/go-server/src/helpers"
+)
+
+func runKeysCommand(args []string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "uso: keys rotate [RS256|EdDSA] | keys list")
+		return 2
+	}
+
+	switch args[0] {
+	case "rotate":
+		algorithm := helpers.SigningAlgorithm
+		if len(args) > 1 {
+			algorithm = args[1]
+		}
+
+		key, err := helpers.RotateSigningKey(ctx, algorithm)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Erro ao rotacionar chave de assinatura:", err)
+			return 1
+		}
+
+		fmt.Printf("Nova chave ativa: %s (%s)\n", key.Kid, key.Algorithm)
+		fmt.Printf("Chaves anteriores continuam válidas para verificação por %s\n", helpers.RefreshTokenDuration)
+		return 0
+	case "list":
+		keys, err := helpers.GetSigningKeys(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Erro ao listar chaves de assinatura:", err)
+			return 1
+		}
+
+		for _, key := range keys {
+			expiresAt := "-"
+			if key.ExpiresAt != nil {
+				expiresAt = key.ExpiresAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", key.Kid, key.Algorithm, key.Status, key.CreatedAt.Format(time.RFC3339), expiresAt)
+		}
+		return 0
+	}
+
+	fmt.Fprintln(os.Stderr, "comando desconhecido:", args[0])
+	return 2
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	controllers "github.com/Nooksd/go-server/src/controllers"
+	helpers "github.com/Nooksd/go-server/src/helpers"
+	"github.com/Nooksd/go-server/src/ldapsync"
+)
+
+func runLDAPCommand(args []string) int {
+	if len(args) == 0 || args[0] != "sync" {
+		fmt.Fprintln(os.Stderr, "uso: ldap sync [--commit] [--from arquivo.json]")
+		return 2
+	}
+
+	commit := false
+	from := ""
+
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--commit":
+			commit = true
+		case "--from":
+			if i+1 >= len(args) {
+				fmt.Fprintln(os.Stderr, "--from requer um arquivo")
+				return 2
+			}
+			i++
+			from = args[i]
+		default:
+			fmt.Fprintln(os.Stderr, "opção desconhecida:", args[i])
+			return 2
+		}
+	}
+
+	var directory ldapsync.Directory
+	var err error
+	if from != "" {
+		directory, err = ldapsync.LoadStaticDirectory(from)
+	} else {
+		directory, err = ldapsync.NewDirectory(helpers.LDAP)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Erro ao abrir diretório:", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	report := controllers.RunLDAPSync(ctx, directory, "cli", "", !commit)
+
+	output, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(output))
+
+	if report.Error != "" {
+		return 1
+	}
+	return 0
+}
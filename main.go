@@ -3,12 +3,23 @@ package main
 import (
 	"os"
 
+	controllers "github.com/Nooksd/go-server/src/controllers"
+	helpers "github.com/Nooksd/go-server/src/helpers"
 	routes "github.com/Nooksd/go-server/src/routes"
 
 	"github.com/gin-gonic/gin"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "keys" {
+		os.Exit(runKeysCommand(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "ldap" {
+		os.Exit(runLDAPCommand(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrateCommand(os.Args[2:]))
+	}
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -18,14 +29,22 @@ func main() {
 	router := gin.New()
 	router.Use(gin.Logger())
 
+	routes.WellKnownRoutes(router)
 	routes.AuthRoutes(router)
 	routes.ImageRoutes(router)
 
 	routes.UserRoutes(router)
+	routes.DepartmentRoutes(router)
 	routes.PostRoutes(router)
 	routes.MissionsRoutes(router)
 	routes.ValidationRoutes(router)
 	routes.NotificationRoutes(router)
+	routes.AdminRoutes(router)
+
+	helpers.StartAnniversaryJob()
+	helpers.StartBirthdayJob()
+	helpers.StartFeedRankingJob()
+	controllers.StartLDAPSyncJob()
 
 	router.Run(":" + port)
 }
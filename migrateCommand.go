@@ -0,0 +1,23 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	controllers "github.com/Nooksd/go-server/src/controllers"
+)
+
+func runMigrateCommand(args []string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	if err := controllers.MigrateUserSearchIndex(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Erro ao migrar índice de busca de usuários:", err)
+		return 1
+	}
+
+	fmt.Println("Índice de busca de usuários migrado")
+	return 0
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	helper "github.com/Nooksd/go-server/src/helpers"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const maxAnniversaryDays = 366
+
+func GetAnniversaries() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+		if err != nil || days < 1 || days > maxAnniversaryDays {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Quantidade de dias inválida"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		anniversaries, err := helper.UpcomingAnniversaries(ctx, helper.CompanyNow(), days)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar aniversários de empresa"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"anniversaries": anniversaries})
+	}
+}
+
+func GetAnniversarySettings() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		c.JSON(http.StatusOK, gin.H{"settings": helper.GetAnniversarySettings(ctx)})
+	}
+}
+
+func UpdateAnniversarySettings() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaims, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			return
+		}
+
+		claims, ok := userClaims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		settings := helper.GetAnniversarySettings(ctx)
+
+		if err := c.ShouldBindJSON(&settings); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+			return
+		}
+
+		validationErrors := validate.Struct(settings)
+		if validationErrors != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErrors.Error()})
+			return
+		}
+
+		settings.UpdatedBy = claims["Uid"].(string)
+
+		if err := helper.SaveAnniversarySettings(ctx, settings); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar configurações"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Configurações atualizadas com sucesso", "settings": settings})
+	}
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var auditCollection *mongo.Collection = database.OpenCollection(database.Client, "auditLog")
+
+func parseAuditTime(value string) (time.Time, bool) {
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, true
+	}
+	if date, err := time.ParseInLocation("2006-01-02", value, helper.CompanyLocation); err == nil {
+		return date, true
+	}
+	return time.Time{}, false
+}
+
+func GetAuditLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := bson.M{}
+
+		for _, field := range []string{"actorId", "impersonatorId", "action", "targetType", "targetId"} {
+			if value := c.Query(field); value != "" {
+				filter[field] = value
+			}
+		}
+
+		createdAt := bson.M{}
+		if from := c.Query("from"); from != "" {
+			date, ok := parseAuditTime(from)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "data inicial inválida"})
+				return
+			}
+			createdAt["$gte"] = date
+		}
+		if to := c.Query("to"); to != "" {
+			date, ok := parseAuditTime(to)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "data final inválida"})
+				return
+			}
+			createdAt["$lt"] = date
+		}
+		if len(createdAt) > 0 {
+			filter["createdAt"] = createdAt
+		}
+
+		if cursorParam := c.Query("cursor"); cursorParam != "" {
+			cursor, err := helper.DecodeCursor(cursorParam)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			value, err := cursor.Time()
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			filter = bson.M{"$and": []bson.M{filter, helper.KeysetFilter("createdAt", value, cursor.Id, true)}}
+		}
+
+		limit := helper.ParsePageLimit(c.Query("limit"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		opts := options.Find().
+			SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+			SetLimit(int64(limit + 1))
+
+		cursor, err := auditCollection.Find(ctx, filter, opts)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar log de auditoria"})
+			return
+		}
+		defer cursor.Close(ctx)
+
+		entries := []model.AuditEntry{}
+		if err = cursor.All(ctx, &entries); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar log de auditoria"})
+			return
+		}
+
+		hasMore := len(entries) > limit
+		nextCursor := ""
+		if hasMore {
+			entries = entries[:limit]
+			last := entries[limit-1]
+			nextCursor = helper.EncodeTimeCursor(last.CreatedAt, last.ID)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"entries": entries, "nextCursor": nextCursor, "hasMore": hasMore})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	helper "github.com/Nooksd/go-server/src/helpers"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -26,10 +27,9 @@ func UploadAvatar() gin.HandlerFunc {
 			return
 		}
 
-		userType := claims["UserType"].(string)
 		userId := claims["Uid"].(string)
 
-		if userType != "ADMIN" && userId != targetUserId {
+		if userId != targetUserId && !helper.HasClaimsPermission(claims, helper.PermUserUpdate) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Você não tem permissão para atualizar este usuário"})
 			return
 		}
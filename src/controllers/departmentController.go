@@ -0,0 +1,394 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var departmentCollection *mongo.Collection = database.OpenCollection(database.Client, "departments")
+
+var orgNodeProjection = bson.M{
+	"uid":               1,
+	"name":              1,
+	"role":              1,
+	"profilePictureUrl": 1,
+	"departmentId":      1,
+	"managerId":         1,
+}
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := userCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.M{"departmentId": 1}},
+		{Keys: bson.M{"managerId": 1}},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de hierarquia: %v\n", err)
+	}
+}
+
+func departmentExists(ctx context.Context, departmentId string) bool {
+	id, err := primitive.ObjectIDFromHex(departmentId)
+	if err != nil {
+		return false
+	}
+
+	count, err := departmentCollection.CountDocuments(ctx, bson.M{"_id": id})
+	return err == nil && count > 0
+}
+
+func createsManagerCycle(ctx context.Context, userId string, managerId string) (bool, error) {
+	visited := map[string]bool{}
+	current := managerId
+
+	for current != "" {
+		if current == userId {
+			return true, nil
+		}
+		if visited[current] {
+			return true, nil
+		}
+		visited[current] = true
+
+		var manager model.User
+		filter := bson.M{"uid": current, "active": bson.M{"$ne": false}}
+		err := userCollection.FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{"managerId": 1})).Decode(&manager)
+		if err != nil {
+			if current == managerId {
+				return false, errors.New("Gestor não encontrado")
+			}
+			return false, nil
+		}
+
+		current = manager.ManagerId
+	}
+
+	return false, nil
+}
+
+func loadOrgNodes(ctx context.Context, filter bson.M) (map[string]*model.OrgNode, error) {
+	query := bson.M{"active": bson.M{"$ne": false}}
+	for key, value := range filter {
+		query[key] = value
+	}
+
+	cursor, err := userCollection.Find(ctx, query, options.Find().SetProjection(orgNodeProjection))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []model.OrgNode
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*model.OrgNode, len(list))
+	for i := range list {
+		list[i].Reports = []*model.OrgNode{}
+		nodes[list[i].Uid] = &list[i]
+	}
+
+	return nodes, nil
+}
+
+func linkOrgNodes(nodes map[string]*model.OrgNode) []*model.OrgNode {
+	roots := []*model.OrgNode{}
+
+	for _, node := range nodes {
+		manager, ok := nodes[node.ManagerId]
+		if node.ManagerId == "" || !ok || manager == node {
+			roots = append(roots, node)
+			continue
+		}
+		manager.Reports = append(manager.Reports, node)
+	}
+
+	for _, node := range nodes {
+		sortOrgNodes(node.Reports)
+	}
+	sortOrgNodes(roots)
+
+	return roots
+}
+
+func sortOrgNodes(nodes []*model.OrgNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+}
+
+func CreateDepartment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var department model.Department
+
+		if err := c.ShouldBindJSON(&department); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+			return
+		}
+
+		department.ID = primitive.NewObjectID()
+		department.CreatedAt = time.Now()
+		department.UpdatedAt = department.CreatedAt
+
+		validationErrors := validate.Struct(department)
+		if validationErrors != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErrors.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if department.HeadId != "" && !helper.IsUserActive(department.HeadId) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Responsável não encontrado"})
+			return
+		}
+
+		_, err := departmentCollection.InsertOne(ctx, department)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar departamento"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"message": "Departamento criado com sucesso", "department": department})
+	}
+}
+
+func GetDepartments() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		cursor, err := departmentCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"name": 1}))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar departamentos"})
+			return
+		}
+		defer cursor.Close(ctx)
+
+		departments := []model.Department{}
+		if err = cursor.All(ctx, &departments); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar departamentos"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"departments": departments})
+	}
+}
+
+func GetDepartment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		departmentId, err := primitive.ObjectIDFromHex(c.Param("departmentId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID do departamento inválido"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var department model.Department
+		err = departmentCollection.FindOne(ctx, bson.M{"_id": departmentId}).Decode(&department)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Departamento não encontrado"})
+			return
+		}
+
+		nodes, err := loadOrgNodes(ctx, bson.M{"departmentId": department.ID.Hex()})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar membros do departamento"})
+			return
+		}
+
+		members := []*model.OrgNode{}
+		for _, node := range nodes {
+			members = append(members, node)
+		}
+		sortOrgNodes(members)
+
+		c.JSON(http.StatusOK, gin.H{"department": department, "members": members})
+	}
+}
+
+func UpdateDepartment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		departmentId, err := primitive.ObjectIDFromHex(c.Param("departmentId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID do departamento inválido"})
+			return
+		}
+
+		var request struct {
+			Name        *string `json:"name" validate:"omitempty,min=1,max=120"`
+			Description *string `json:"description"`
+			HeadId      *string `json:"headId" validate:"omitempty,mongodb|eq="`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+			return
+		}
+
+		validationErrors := validate.Struct(request)
+		if validationErrors != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErrors.Error()})
+			return
+		}
+
+		update := bson.M{"updatedAt": time.Now()}
+		if request.Name != nil {
+			update["name"] = *request.Name
+		}
+		if request.Description != nil {
+			update["description"] = *request.Description
+		}
+		if request.HeadId != nil {
+			if *request.HeadId != "" && !helper.IsUserActive(*request.HeadId) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Responsável não encontrado"})
+				return
+			}
+			update["headId"] = *request.HeadId
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var department model.Department
+		err = departmentCollection.FindOneAndUpdate(
+			ctx,
+			bson.M{"_id": departmentId},
+			bson.M{"$set": update},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&department)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Departamento não encontrado"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Departamento atualizado com sucesso", "department": department})
+	}
+}
+
+func DeleteDepartment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		departmentIdParam := c.Param("departmentId")
+
+		departmentId, err := primitive.ObjectIDFromHex(departmentIdParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID do departamento inválido"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		count, err := userCollection.CountDocuments(ctx, bson.M{"departmentId": departmentIdParam, "active": bson.M{"$ne": false}})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao verificar membros do departamento"})
+			return
+		}
+
+		if count > 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "Departamento possui usuários associados", "users": count})
+			return
+		}
+
+		result, err := departmentCollection.DeleteOne(ctx, bson.M{"_id": departmentId})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao deletar departamento"})
+			return
+		}
+
+		if result.DeletedCount == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Departamento não encontrado"})
+			return
+		}
+
+		_, err = userCollection.UpdateMany(ctx, bson.M{"departmentId": departmentIdParam}, bson.M{"$unset": bson.M{"departmentId": ""}})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao desvincular usuários do departamento"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Departamento deletado com sucesso"})
+	}
+}
+
+func GetOrgChart() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		filter := bson.M{}
+		if departmentId := c.Query("departmentId"); departmentId != "" {
+			filter["departmentId"] = departmentId
+		}
+
+		nodes, err := loadOrgNodes(ctx, filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao montar organograma"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"chart": linkOrgNodes(nodes)})
+	}
+}
+
+func GetUserReports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId := c.Param("userId")
+		recursive := c.Query("recursive") == "true"
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if !recursive {
+			nodes, err := loadOrgNodes(ctx, bson.M{"managerId": userId})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar liderados"})
+				return
+			}
+
+			reports := []*model.OrgNode{}
+			for _, node := range nodes {
+				reports = append(reports, node)
+			}
+			sortOrgNodes(reports)
+
+			c.JSON(http.StatusOK, gin.H{"reports": reports})
+			return
+		}
+
+		nodes, err := loadOrgNodes(ctx, bson.M{})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar liderados"})
+			return
+		}
+
+		linkOrgNodes(nodes)
+
+		root, ok := nodes[userId]
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"reports": root.Reports})
+	}
+}
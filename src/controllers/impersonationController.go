@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	helper "github.com/Nooksd/go-server/src/helpers"
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func StartImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaims, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			return
+		}
+
+		claims, ok := userClaims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
+			return
+		}
+
+		impersonatorUid, _ := claims["Uid"].(string)
+		if helper.IsServiceAccount(claims) || impersonatorUid == "" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Personificação disponível apenas para administradores"})
+			return
+		}
+
+		userId := c.Param("userId")
+		if userId == impersonatorUid {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Você não pode personificar sua própria conta"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var user model.User
+		if err := userCollection.FindOne(ctx, bson.M{"uid": userId}).Decode(&user); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+
+		if !user.IsActive() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Usuário desativado"})
+			return
+		}
+
+		if user.UserType == nil || !helper.CanAssignRole(claims, *user.UserType) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Não é permitido personificar um usuário com permissões que você não possui"})
+			return
+		}
+
+		if helper.HasPermission(*user.UserType, helper.PermUserImpersonate) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Não é permitido personificar outro administrador"})
+			return
+		}
+
+		session, err := helper.CreateImpersonationSession(user.Uid, impersonatorUid, c.Request.UserAgent(), c.ClientIP())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar sessão"})
+			return
+		}
+
+		accessToken, err := helper.GenerateImpersonationToken(
+			*user.Email,
+			*user.Name,
+			user.ProfilePictureUrl,
+			*user.Role,
+			user.Uid,
+			*user.UserType,
+			session.ID.Hex(),
+			impersonatorUid,
+			session.ExpiresAt,
+		)
+		if err != nil {
+			helper.RevokeSession(session.ID.Hex(), "impersonation-error")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar token"})
+			return
+		}
+
+		helper.Audit(c, helper.AuditImpersonationStart, "user", user.Uid, nil, bson.M{
+			"sessionId": session.ID.Hex(),
+			"expiresAt": session.ExpiresAt,
+		})
+
+		helper.ApplyPrivacy(&user, helper.Viewer{Uid: user.Uid})
+
+		c.JSON(http.StatusOK, gin.H{
+			"accessToken":     accessToken,
+			"expiresAt":       session.ExpiresAt,
+			"sessionId":       session.ID.Hex(),
+			"impersonatorUid": impersonatorUid,
+			"user":            user,
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var invitationCollection *mongo.Collection = database.OpenCollection(database.Client, "invitations")
+
+var invitationDuration = time.Hour * 72
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := invitationCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.M{"tokenHash": 1}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.M{"userId": 1}},
+		{Keys: bson.M{"expiresAt": 1}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de convites: %v\n", err)
+	}
+}
+
+func invitationLink(token string) string {
+	inviteUrl := os.Getenv("INVITE_URL")
+	if inviteUrl == "" {
+		inviteUrl = "http://192.168.1.68:9000/auth/accept-invite"
+	}
+
+	return inviteUrl + "?token=" + token
+}
+
+func revokeInvitations(ctx context.Context, userId string) {
+	_, err := invitationCollection.DeleteMany(ctx, bson.M{"userId": userId, "acceptedAt": bson.M{"$exists": false}})
+	if err != nil {
+		log.Printf("Erro ao invalidar convites anteriores: %v\n", err)
+	}
+}
+
+func createInvitation(ctx context.Context, user model.User, invitedBy string, ip string) (string, model.Invitation, error) {
+	token := helper.NewTokenId()
+	now := time.Now()
+
+	invitation := model.Invitation{
+		ID:        primitive.NewObjectID(),
+		UserId:    user.Uid,
+		TokenHash: helper.HashToken(token),
+		InvitedBy: invitedBy,
+		IP:        ip,
+		CreatedAt: now,
+		ExpiresAt: now.Add(invitationDuration),
+	}
+
+	if _, err := invitationCollection.InsertOne(ctx, invitation); err != nil {
+		return "", model.Invitation{}, err
+	}
+
+	return token, invitation, nil
+}
+
+func sendInvitation(user model.User, token string) error {
+	return helper.SendMail(
+		*user.Email,
+		"Bem-vindo(a) ao Connect",
+		fmt.Sprintf(
+			"Olá, %s!\n\nSua conta foi criada. Acesse o link abaixo para definir sua senha e ativar seu acesso. O convite é válido por %d horas.\n\n%s",
+			*user.Name,
+			int(invitationDuration.Hours()),
+			invitationLink(token),
+		),
+	)
+}
+
+func inviteUser(ctx context.Context, c *gin.Context, user model.User) (gin.H, error) {
+	revokeInvitations(ctx, user.Uid)
+
+	token, invitation, err := createInvitation(ctx, user, helper.GetClaim(c, "Uid"), c.ClientIP())
+	if err != nil {
+		return nil, err
+	}
+
+	response := gin.H{"expiresAt": invitation.ExpiresAt}
+
+	if c.Query("delivery") == "link" {
+		response["delivery"] = "link"
+		response["inviteUrl"] = invitationLink(token)
+	} else {
+		response["delivery"] = "email"
+		if err := sendInvitation(user, token); err != nil {
+			log.Printf("Erro ao enviar convite para %s: %v\n", *user.Email, err)
+			response["delivery"] = "failed"
+		}
+	}
+
+	helper.Audit(c, helper.AuditUserInvite, "user", user.Uid, nil, bson.M{"expiresAt": invitation.ExpiresAt, "delivery": response["delivery"]})
+
+	return response, nil
+}
+
+func ResendInvitation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId := c.Param("userId")
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var user model.User
+		if err := userCollection.FindOne(ctx, bson.M{"uid": userId}).Decode(&user); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+
+		if !user.Pending {
+			c.JSON(http.StatusConflict, gin.H{"error": "Usuário já ativou sua conta"})
+			return
+		}
+
+		if !user.IsActive() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Usuário desativado"})
+			return
+		}
+
+		invitation, err := inviteUser(ctx, c, user)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar convite"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"uid": user.Uid, "invitation": invitation})
+	}
+}
+
+func GetInvitation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.Query("token")
+		if token == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "token é obrigatório"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		filter := bson.M{
+			"tokenHash":  helper.HashToken(token),
+			"acceptedAt": bson.M{"$exists": false},
+			"expiresAt":  bson.M{"$gt": time.Now()},
+		}
+
+		var invitation model.Invitation
+		if err := invitationCollection.FindOne(ctx, filter).Decode(&invitation); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "convite inválido ou expirado"})
+			return
+		}
+
+		var user model.User
+		projection := bson.M{"name": 1, "email": 1}
+		if err := userCollection.FindOne(ctx, bson.M{"uid": invitation.UserId}, options.FindOne().SetProjection(projection)).Decode(&user); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"name": user.Name, "email": user.Email, "expiresAt": invitation.ExpiresAt})
+	}
+}
+
+func AcceptInvite() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			Token    string `json:"token" validate:"required"`
+			Password string `json:"password" validate:"required"`
+			Birthday string `json:"birthday" validate:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "erro ao ler dados"})
+			return
+		}
+
+		if err := validate.Struct(request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "token, senha e data de nascimento são obrigatórios"})
+			return
+		}
+
+		birthday, err := parseImportDate(request.Birthday)
+		if err != nil || birthday.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "data de nascimento inválida"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		filter := bson.M{
+			"tokenHash":  helper.HashToken(request.Token),
+			"acceptedAt": bson.M{"$exists": false},
+			"expiresAt":  bson.M{"$gt": time.Now()},
+		}
+
+		var invitation model.Invitation
+		if err := invitationCollection.FindOne(ctx, filter).Decode(&invitation); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "convite inválido ou expirado"})
+			return
+		}
+
+		var user model.User
+		if err := userCollection.FindOne(ctx, bson.M{"uid": invitation.UserId, "pending": true}).Decode(&user); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "convite inválido ou expirado"})
+			return
+		}
+
+		if !user.IsActive() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Usuário desativado"})
+			return
+		}
+
+		if err := helper.DefaultPasswordPolicy.Validate(request.Password, user); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		result, err := invitationCollection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"acceptedAt": time.Now()}})
+		if err != nil || result.ModifiedCount == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "convite inválido ou expirado"})
+			return
+		}
+
+		activated, err := userCollection.UpdateOne(
+			ctx,
+			bson.M{"uid": user.Uid, "pending": true},
+			bson.M{
+				"$set":   bson.M{"password": HashPassword(request.Password), "birthday": birthday},
+				"$unset": bson.M{"pending": ""},
+			},
+		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ativar conta"})
+			return
+		}
+		if activated.MatchedCount == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "convite inválido ou expirado"})
+			return
+		}
+
+		revokeInvitations(ctx, user.Uid)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Conta ativada com sucesso"})
+	}
+}
@@ -0,0 +1,15 @@
+package controllers
+
+import (
+	"net/http"
+
+	helper "github.com/Nooksd/go-server/src/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+func GetJWKS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, helper.JWKS())
+	}
+}
@@ -0,0 +1,296 @@
+package controllers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	"github.com/Nooksd/go-server/src/ldapsync"
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const ldapSyncActor = ldapsync.Actor
+const ldapSyncJob = "ldap-sync"
+
+var ldapSyncReportCollection *mongo.Collection = database.OpenCollection(database.Client, "ldapSyncReports")
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := ldapSyncReportCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "startedAt", Value: -1}, {Key: "_id", Value: -1}},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de relatórios LDAP: %v\n", err)
+	}
+
+	_, err = userCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.M{"ldapDn": 1},
+		Options: options.Index().SetSparse(true),
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índice LDAP de usuários: %v\n", err)
+	}
+}
+
+type ldapUserStore struct{}
+
+func (ldapUserStore) RoleExists(name string) bool {
+	return helper.RoleExists(name)
+}
+
+func (ldapUserStore) Users(ctx context.Context, emails []string) ([]model.User, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"ldapDn": bson.M{"$exists": true}},
+		{"email": bson.M{"$in": emails}},
+	}}
+	projection := bson.M{
+		"uid": 1, "name": 1, "email": 1, "role": 1, "phoneNumber": 1, "managerId": 1,
+		"ldapDn": 1, "active": 1, "deactivatedBy": 1,
+	}
+
+	cursor, err := userCollection.Find(ctx, filter, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	users := []model.User{}
+	err = cursor.All(ctx, &users)
+	return users, err
+}
+
+func (ldapUserStore) CreateUser(ctx context.Context, user model.User) (model.User, error) {
+	return insertImportedUser(ctx, user)
+}
+
+func (ldapUserStore) UpdateUser(ctx context.Context, uid string, updates bson.M, reactivate bool) error {
+	update := bson.M{}
+	if reactivate {
+		updates["active"] = true
+		update["$unset"] = bson.M{"deactivatedAt": "", "deactivatedBy": ""}
+	}
+	if len(updates) > 0 {
+		update["$set"] = updates
+	}
+
+	if _, err := userCollection.UpdateOne(ctx, bson.M{"uid": uid}, update); err != nil {
+		return err
+	}
+
+	if reactivate {
+		helper.InvalidateUserStatus(uid)
+	}
+	return nil
+}
+
+func (ldapUserStore) ManagerCycle(ctx context.Context, uid string, managerId string) (bool, error) {
+	return createsManagerCycle(ctx, uid, managerId)
+}
+
+func (ldapUserStore) SetManager(ctx context.Context, uid string, managerId string) error {
+	update := bson.M{"$unset": bson.M{"managerId": ""}}
+	if managerId != "" {
+		update = bson.M{"$set": bson.M{"managerId": managerId}}
+	}
+
+	_, err := userCollection.UpdateOne(ctx, bson.M{"uid": uid}, update)
+	return err
+}
+
+func (ldapUserStore) DeactivateUser(ctx context.Context, uid string) error {
+	_, err := userCollection.UpdateOne(
+		ctx,
+		bson.M{"uid": uid, "active": bson.M{"$ne": false}},
+		bson.M{"$set": bson.M{
+			"active":        false,
+			"deactivatedAt": time.Now(),
+			"deactivatedBy": ldapSyncActor,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	revokeUserAccess(ctx, uid)
+	return nil
+}
+
+func RunLDAPSync(ctx context.Context, directory ldapsync.Directory, trigger string, actorId string, dryRun bool) model.LDAPSyncReport {
+	report, created := ldapsync.Run(ctx, directory, ldapUserStore{}, ldapsync.Options{
+		Trigger:         trigger,
+		ActorId:         actorId,
+		DryRun:          dryRun,
+		DefaultUserType: helper.LDAP.DefaultUserType,
+		Deactivate:      helper.LDAP.Deactivate,
+	})
+
+	sendImportInvitations(ldapSyncActor, "", created)
+
+	if len(created) > 0 {
+		createdNames := make([]string, 0, len(created))
+		for _, user := range created {
+			createdNames = append(createdNames, *user.Name)
+		}
+		helper.CreateNotification(newColleaguesText(createdNames), "contact")
+	}
+
+	if _, err := ldapSyncReportCollection.InsertOne(ctx, report); err != nil {
+		log.Printf("Erro ao salvar relatório de sincronização LDAP: %v\n", err)
+	}
+	return report
+}
+
+func runScheduledLDAPSync(now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	key := now.Truncate(helper.LDAP.Interval).UTC().Format(time.RFC3339)
+	if !helper.ClaimJobRun(ctx, ldapSyncJob, key) {
+		return
+	}
+
+	directory, err := ldapsync.NewDirectory(helper.LDAP)
+	if err != nil {
+		log.Printf("Erro na sincronização LDAP agendada: %v\n", err)
+		return
+	}
+
+	report := RunLDAPSync(ctx, directory, "schedule", "", false)
+	if report.Error != "" {
+		log.Printf("Sincronização LDAP falhou: %s\n", report.Error)
+		return
+	}
+	log.Printf("Sincronização LDAP concluída: %v\n", report.Summary)
+}
+
+func StartLDAPSyncJob() {
+	if !helper.LDAP.Enabled() || helper.LDAP.Interval <= 0 {
+		return
+	}
+	helper.RunPeriodically(ldapSyncJob, helper.LDAP.Interval, runScheduledLDAPSync)
+}
+
+func SyncLDAP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		directory, err := ldapsync.NewDirectory(helper.LDAP)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		dryRun := c.DefaultQuery("mode", "dry-run") != "commit"
+
+		actorId := ""
+		if userClaims, exists := c.Get("user"); exists {
+			if claims, ok := userClaims.(jwt.MapClaims); ok {
+				actorId, _ = claims["Uid"].(string)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		report := RunLDAPSync(ctx, directory, "api", actorId, dryRun)
+		if report.Error != "" {
+			c.JSON(http.StatusBadGateway, gin.H{"error": report.Error, "report": report})
+			return
+		}
+
+		if !dryRun {
+			helper.Audit(c, helper.AuditLDAPSync, "ldapSync", report.ID.Hex(), nil, report.Summary)
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+func GetLDAPSyncReports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := bson.M{}
+
+		if cursorParam := c.Query("cursor"); cursorParam != "" {
+			cursor, err := helper.DecodeCursor(cursorParam)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			value, err := cursor.Time()
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			filter = helper.KeysetFilter("startedAt", value, cursor.Id, true)
+		}
+
+		limit := helper.ParsePageLimit(c.Query("limit"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		opts := options.Find().
+			SetSort(bson.D{{Key: "startedAt", Value: -1}, {Key: "_id", Value: -1}}).
+			SetProjection(bson.M{"entries": 0}).
+			SetLimit(int64(limit + 1))
+
+		cursor, err := ldapSyncReportCollection.Find(ctx, filter, opts)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar relatórios de sincronização"})
+			return
+		}
+		defer cursor.Close(ctx)
+
+		reports := []model.LDAPSyncReport{}
+		if err = cursor.All(ctx, &reports); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar relatórios de sincronização"})
+			return
+		}
+
+		hasMore := len(reports) > limit
+		nextCursor := ""
+		if hasMore {
+			reports = reports[:limit]
+			last := reports[limit-1]
+			nextCursor = helper.EncodeTimeCursor(last.StartedAt, last.ID)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"reports": reports, "nextCursor": nextCursor, "hasMore": hasMore})
+	}
+}
+
+func GetLDAPSyncReport() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reportId, err := primitive.ObjectIDFromHex(c.Param("reportId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID de relatório inválido"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var report model.LDAPSyncReport
+		if err := ldapSyncReportCollection.FindOne(ctx, bson.M{"_id": reportId}).Decode(&report); err != nil {
+			if err == mongo.ErrNoDocuments {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Relatório não encontrado"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar relatório"})
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
@@ -0,0 +1,381 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	helper "github.com/Nooksd/go-server/src/helpers"
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func startMfaSetup(ctx context.Context, user model.User) (gin.H, error) {
+	secret := helper.GenerateTOTPSecret()
+
+	_, err := userCollection.UpdateOne(ctx, bson.M{"uid": user.Uid}, bson.M{"$set": bson.M{"mfaPendingSecret": secret}})
+	if err != nil {
+		return nil, err
+	}
+
+	return gin.H{
+		"secret":          secret,
+		"provisioningUri": helper.TOTPProvisioningURI(secret, *user.Email),
+	}, nil
+}
+
+func confirmMfaSetup(ctx context.Context, user model.User, code string) ([]string, bool, error) {
+	if user.MfaPendingSecret == "" {
+		return nil, false, nil
+	}
+
+	step, valid := helper.VerifyTOTP(user.MfaPendingSecret, code, 0)
+	if !valid {
+		return nil, false, nil
+	}
+
+	codes, hashes := helper.GenerateRecoveryCodes()
+
+	_, err := userCollection.UpdateOne(
+		ctx,
+		bson.M{"uid": user.Uid},
+		bson.M{
+			"$set": bson.M{
+				"mfaEnabled":       true,
+				"mfaSecret":        user.MfaPendingSecret,
+				"mfaLastStep":      step,
+				"mfaRecoveryCodes": hashes,
+			},
+			"$unset": bson.M{"mfaPendingSecret": ""},
+		},
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return codes, true, nil
+}
+
+func verifyMfaCode(ctx context.Context, user model.User, code string, recoveryCode string) bool {
+	if !user.MfaEnabled {
+		return false
+	}
+
+	if recoveryCode != "" {
+		hash := helper.HashRecoveryCode(recoveryCode)
+		result, err := userCollection.UpdateOne(
+			ctx,
+			bson.M{"uid": user.Uid, "mfaRecoveryCodes": hash},
+			bson.M{"$pull": bson.M{"mfaRecoveryCodes": hash}},
+		)
+		return err == nil && result.ModifiedCount == 1
+	}
+
+	step, valid := helper.VerifyTOTP(user.MfaSecret, code, user.MfaLastStep)
+	if !valid {
+		return false
+	}
+
+	result, err := userCollection.UpdateOne(
+		ctx,
+		bson.M{"uid": user.Uid, "mfaLastStep": user.MfaLastStep},
+		bson.M{"$set": bson.M{"mfaLastStep": step}},
+	)
+	return err == nil && result.ModifiedCount == 1
+}
+
+func findMfaUser(c *gin.Context, ctx context.Context, mfaToken string) (model.User, bool) {
+	uid, err := helper.ParseMfaToken(mfaToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return model.User{}, false
+	}
+
+	var user model.User
+	if err := userCollection.FindOne(ctx, bson.M{"uid": uid}).Decode(&user); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+		return model.User{}, false
+	}
+
+	return user, true
+}
+
+func SetupMfaLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			MfaToken string `json:"mfaToken" validate:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil || validate.Struct(request) != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "token de verificação é obrigatório"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		user, ok := findMfaUser(c, ctx, request.MfaToken)
+		if !ok {
+			return
+		}
+
+		if user.MfaEnabled {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Autenticação em dois fatores já está ativa"})
+			return
+		}
+
+		setup, err := startMfaSetup(ctx, user)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar autenticação em dois fatores"})
+			return
+		}
+
+		c.JSON(http.StatusOK, setup)
+	}
+}
+
+func VerifyMfaLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			MfaToken     string `json:"mfaToken" validate:"required"`
+			Code         string `json:"code"`
+			RecoveryCode string `json:"recoveryCode"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil || validate.Struct(request) != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "token de verificação é obrigatório"})
+			return
+		}
+
+		if request.Code == "" && request.RecoveryCode == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "código ou código de recuperação é obrigatório"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		user, ok := findMfaUser(c, ctx, request.MfaToken)
+		if !ok {
+			return
+		}
+
+		if retryAfter := helper.CheckLoginAllowed(*user.Email, c.ClientIP()); retryAfter > 0 {
+			respondLoginLocked(c, retryAfter)
+			return
+		}
+
+		if !user.MfaEnabled {
+			codes, valid, err := confirmMfaSetup(ctx, user, request.Code)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ativar autenticação em dois fatores"})
+				return
+			}
+
+			if !valid {
+				if retryAfter := helper.RegisterLoginFailure(*user.Email, c.ClientIP(), user.Uid); retryAfter > 0 {
+					respondLoginLocked(c, retryAfter)
+					return
+				}
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "código inválido"})
+				return
+			}
+
+			user.MfaEnabled = true
+			issueLoginTokens(c, user, gin.H{"recoveryCodes": codes})
+			return
+		}
+
+		if !verifyMfaCode(ctx, user, request.Code, request.RecoveryCode) {
+			if retryAfter := helper.RegisterLoginFailure(*user.Email, c.ClientIP(), user.Uid); retryAfter > 0 {
+				respondLoginLocked(c, retryAfter)
+				return
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "código inválido"})
+			return
+		}
+
+		issueLoginTokens(c, user, gin.H{})
+	}
+}
+
+func findCurrentUser(c *gin.Context, ctx context.Context) (model.User, bool) {
+	userClaims, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return model.User{}, false
+	}
+
+	claims, ok := userClaims.(jwt.MapClaims)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
+		return model.User{}, false
+	}
+
+	var user model.User
+	if err := userCollection.FindOne(ctx, bson.M{"uid": claims["Uid"].(string)}).Decode(&user); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+		return model.User{}, false
+	}
+
+	return user, true
+}
+
+func SetupMfa() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		user, ok := findCurrentUser(c, ctx)
+		if !ok {
+			return
+		}
+
+		if user.MfaEnabled {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Autenticação em dois fatores já está ativa"})
+			return
+		}
+
+		setup, err := startMfaSetup(ctx, user)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar autenticação em dois fatores"})
+			return
+		}
+
+		c.JSON(http.StatusOK, setup)
+	}
+}
+
+func EnableMfa() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			Code string `json:"code" validate:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil || validate.Struct(request) != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "código é obrigatório"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		user, ok := findCurrentUser(c, ctx)
+		if !ok {
+			return
+		}
+
+		if user.MfaEnabled {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Autenticação em dois fatores já está ativa"})
+			return
+		}
+
+		codes, valid, err := confirmMfaSetup(ctx, user, request.Code)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ativar autenticação em dois fatores"})
+			return
+		}
+
+		if !valid {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "código inválido"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Autenticação em dois fatores ativada com sucesso", "recoveryCodes": codes})
+	}
+}
+
+func DisableMfa() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			Password string `json:"password" validate:"required"`
+			Code     string `json:"code" validate:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil || validate.Struct(request) != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "senha e código são obrigatórios"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		user, ok := findCurrentUser(c, ctx)
+		if !ok {
+			return
+		}
+
+		if !user.MfaEnabled {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Autenticação em dois fatores não está ativa"})
+			return
+		}
+
+		if helper.RoleRequiresMfa(*user.UserType) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Autenticação em dois fatores é obrigatória para este tipo de usuário"})
+			return
+		}
+
+		if user.Password == nil || VerifyPassword(request.Password, *user.Password) != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "senha incorreta"})
+			return
+		}
+
+		if !verifyMfaCode(ctx, user, request.Code, "") {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "código inválido"})
+			return
+		}
+
+		_, err := userCollection.UpdateOne(
+			ctx,
+			bson.M{"uid": user.Uid},
+			bson.M{
+				"$set":   bson.M{"mfaEnabled": false},
+				"$unset": bson.M{"mfaSecret": "", "mfaPendingSecret": "", "mfaLastStep": "", "mfaRecoveryCodes": ""},
+			},
+		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao desativar autenticação em dois fatores"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Autenticação em dois fatores desativada com sucesso"})
+	}
+}
+
+func RegenerateRecoveryCodes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			Code string `json:"code" validate:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil || validate.Struct(request) != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "código é obrigatório"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		user, ok := findCurrentUser(c, ctx)
+		if !ok {
+			return
+		}
+
+		if !verifyMfaCode(ctx, user, request.Code, "") {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "código inválido"})
+			return
+		}
+
+		codes, hashes := helper.GenerateRecoveryCodes()
+
+		_, err := userCollection.UpdateOne(ctx, bson.M{"uid": user.Uid}, bson.M{"$set": bson.M{"mfaRecoveryCodes": hashes}})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar códigos de recuperação"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"recoveryCodes": codes})
+	}
+}
@@ -34,13 +34,6 @@ func CreateMission() gin.HandlerFunc {
 			return
 		}
 
-		userType := claims["UserType"].(string)
-
-		if userType != "ADMIN" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário sem permissão", "tipo": userType})
-			return
-		}
-
 		var mission model.Missions
 
 		if err := c.ShouldBindJSON(&mission); err != nil {
@@ -74,6 +67,8 @@ func CreateMission() gin.HandlerFunc {
 			return
 		}
 
+		helper.Audit(c, helper.AuditMissionCreate, "mission", mission.ID.Hex(), nil, mission)
+
 		helper.CreateNotification(
 			fmt.Sprintf(
 				"Nova missão de %d disponível!",
@@ -139,27 +134,9 @@ func GetCurrentMissions() gin.HandlerFunc {
 
 func CompleteMission() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userClaims, exists := c.Get("user")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
-			return
-		}
-
-		claims, ok := userClaims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
-			return
-		}
-
-		userType := claims["UserType"].(string)
 		missionIdParam := c.Param("missionId")
 		userId := c.Param("userId")
 
-		if userType != "ADMIN" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário sem permissão"})
-			return
-		}
-
 		missionId, err := primitive.ObjectIDFromHex(missionIdParam)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "ID da missão inválido"})
@@ -210,31 +187,14 @@ func CompleteMission() gin.HandlerFunc {
 			return
 		}
 
+		helper.Audit(c, helper.AuditMissionComplete, "mission", missionIdParam, nil, bson.M{"userId": userId, "points": mission.Value})
+
 		c.JSON(http.StatusOK, gin.H{"message": "Missão completada com sucesso"})
 	}
 }
 
 func DeleteMission() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userClaims, exists := c.Get("user")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
-			return
-		}
-
-		claims, ok := userClaims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
-			return
-		}
-
-		userType := claims["UserType"].(string)
-
-		if userType != "ADMIN" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário sem permissão"})
-			return
-		}
-
 		missionIdParam := c.Param("missionId")
 
 		missionId, err := primitive.ObjectIDFromHex(missionIdParam)
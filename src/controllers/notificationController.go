@@ -19,25 +19,6 @@ var notificationCollection = database.OpenCollection(database.Client, "notificat
 
 func CreateNotification() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userClaims, exists := c.Get("user")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
-			return
-		}
-
-		claims, ok := userClaims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
-			return
-		}
-
-		var userType = claims["UserType"].(string)
-
-		if userType != "ADMIN" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário sem permissão"})
-			return
-		}
-
 		var notificationRequest struct {
 			Text string `json:"text" validate:"required"`
 			Type string `json:"type" validate:"required"`
@@ -49,6 +30,9 @@ func CreateNotification() gin.HandlerFunc {
 		}
 
 		err := helpers.CreateNotification(notificationRequest.Text, notificationRequest.Type)
+
+		helpers.Audit(c, helpers.AuditNotificationBroadcast, "notification", notificationRequest.Type, nil, notificationRequest)
+
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar e enviar notificação"})
 			return
@@ -157,25 +141,6 @@ func DeleteNotification() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		notificationId := c.Param("notificationId")
 
-		userClaims, exists := c.Get("user")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
-			return
-		}
-
-		claims, ok := userClaims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
-			return
-		}
-
-		var userType = claims["UserType"].(string)
-
-		if userType != "ADMIN" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário sem permissão"})
-			return
-		}
-
 		notificationObjId, err := primitive.ObjectIDFromHex(notificationId)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "ID da notificação inválido"})
@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var oidcStateCollection *mongo.Collection = database.OpenCollection(database.Client, "oidcStates")
+
+// Emails antigos podem ter sido gravados com maiúsculas; a busca pelo email
+// do provedor (já em minúsculas) ignora a diferença de caixa.
+var emailCollation = &options.Collation{Locale: "en", Strength: 2}
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := oidcStateCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.M{"stateHash": 1}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.M{"expiresAt": 1}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de estados OIDC: %v\n", err)
+	}
+
+	_, err = userCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.M{"oidcSubject": 1},
+		Options: options.Index().SetUnique(true).SetSparse(true),
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índice de identidade OIDC: %v\n", err)
+	}
+
+	_, err = userCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.M{"email": 1},
+		Options: options.Index().SetName("email_case_insensitive").SetCollation(emailCollation),
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índice de email sem distinção de maiúsculas: %v\n", err)
+	}
+}
+
+func StartOIDCLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !helper.OIDC.Enabled() {
+			c.JSON(http.StatusNotFound, gin.H{"error": helper.ErrOIDCDisabled.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		state, record := helper.NewOIDCState(c.ClientIP())
+		record.ID = primitive.NewObjectID()
+
+		authorizationUrl, err := helper.OIDCAuthorizationURL(ctx, state, record)
+		if err != nil {
+			log.Printf("Erro ao iniciar login OIDC: %v\n", err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "Erro ao contatar o provedor de identidade"})
+			return
+		}
+
+		if _, err := oidcStateCollection.InsertOne(ctx, record); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar login via SSO"})
+			return
+		}
+
+		if c.Query("mode") == "json" {
+			c.JSON(http.StatusOK, gin.H{"authorizationUrl": authorizationUrl, "state": state})
+			return
+		}
+
+		c.Redirect(http.StatusFound, authorizationUrl)
+	}
+}
+
+func findOrProvisionOIDCUser(ctx context.Context, claims model.OIDCClaims) (model.User, bool, int, error) {
+	var user model.User
+
+	err := userCollection.FindOne(ctx, bson.M{"oidcSubject": claims.Subject}).Decode(&user)
+	if err == nil {
+		return user, false, 0, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return user, false, http.StatusInternalServerError, err
+	}
+
+	err = userCollection.FindOne(ctx, bson.M{"email": claims.Email}, options.FindOne().SetCollation(emailCollation)).Decode(&user)
+	switch {
+	case err == nil:
+		if user.OidcSubject != "" {
+			return user, false, http.StatusConflict, fmt.Errorf("conta já vinculada a outra identidade")
+		}
+
+		_, err = userCollection.UpdateOne(ctx, bson.M{"uid": user.Uid}, bson.M{"$set": bson.M{"oidcSubject": claims.Subject}})
+		if err != nil {
+			return user, false, http.StatusInternalServerError, err
+		}
+		user.OidcSubject = claims.Subject
+		return user, false, 0, nil
+	case err != mongo.ErrNoDocuments:
+		return user, false, http.StatusInternalServerError, err
+	}
+
+	if !helper.OIDC.AutoProvision {
+		return user, false, http.StatusForbidden, fmt.Errorf("usuário não cadastrado")
+	}
+
+	userType := strings.ToUpper(helper.OIDC.DefaultUserType)
+	if !helper.RoleExists(userType) {
+		return user, false, http.StatusInternalServerError, fmt.Errorf("tipo de usuário padrão inválido: %s", userType)
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = strings.Split(claims.Email, "@")[0]
+	}
+	email := claims.Email
+	password := HashPassword(helper.NewTokenId())
+
+	user = model.User{Name: &name, Email: &email, UserType: &userType}
+	applyUserDefaults(&user)
+	user.Password = &password
+	user.OidcSubject = claims.Subject
+	if claims.Picture != "" {
+		user.ProfilePictureUrl = claims.Picture
+	}
+
+	if _, err := userCollection.InsertOne(ctx, user); err != nil {
+		return user, false, http.StatusInternalServerError, err
+	}
+
+	helper.CreateNotification(fmt.Sprintf("Novo funcionário adicionado: %s", name), "contact")
+
+	return user, true, 0, nil
+}
+
+func OIDCCallback() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !helper.OIDC.Enabled() {
+			c.JSON(http.StatusNotFound, gin.H{"error": helper.ErrOIDCDisabled.Error()})
+			return
+		}
+
+		if providerError := c.Query("error"); providerError != "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Login via SSO recusado", "reason": providerError})
+			return
+		}
+
+		state := c.Query("state")
+		code := c.Query("code")
+		if state == "" || code == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "state e code são obrigatórios"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var record model.OIDCState
+		err := oidcStateCollection.FindOneAndDelete(
+			ctx,
+			bson.M{"stateHash": helper.HashToken(state), "expiresAt": bson.M{"$gt": time.Now()}},
+		).Decode(&record)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Sessão de login expirada ou inválida"})
+			return
+		}
+
+		idToken, err := helper.ExchangeOIDCCode(ctx, code, record.CodeVerifier)
+		if err != nil {
+			log.Printf("Erro na troca do código OIDC: %v\n", err)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Não foi possível concluir o login via SSO"})
+			return
+		}
+
+		claims, err := helper.VerifyOIDCIdToken(ctx, idToken, record.Nonce)
+		if err != nil {
+			log.Printf("Erro ao validar token OIDC: %v\n", err)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		if helper.OIDC.RequireVerified && !claims.EmailVerified {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Email não verificado pelo provedor de identidade"})
+			return
+		}
+
+		if !helper.OIDC.DomainAllowed(claims.Email) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Domínio de email não permitido"})
+			return
+		}
+
+		user, provisioned, status, err := findOrProvisionOIDCUser(ctx, claims)
+		if err != nil {
+			if status == http.StatusInternalServerError {
+				log.Printf("Erro ao vincular usuário OIDC: %v\n", err)
+				c.JSON(status, gin.H{"error": "Erro ao vincular usuário"})
+				return
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		completeLogin(c, user, gin.H{"provisioned": provisioned})
+	}
+}
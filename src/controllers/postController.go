@@ -2,11 +2,13 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"path/filepath"
-	"strconv"
+	"sort"
 	"time"
 
 	database "github.com/Nooksd/go-server/src/db"
@@ -23,6 +25,18 @@ import (
 
 var postCollection *mongo.Collection = database.OpenCollection(database.Client, "posts")
 
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := postCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de posts: %v\n", err)
+	}
+}
+
 func contains(slice []string, item string) bool {
 	for _, a := range slice {
 		if a == item {
@@ -32,6 +46,18 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+func checkPostContent(post model.Post) error {
+	if len(post.Hashtags) > 3 {
+		return errors.New("O número máximo de hashtags permitido é 3")
+	}
+
+	if err := validate.Struct(post); err != nil {
+		return err
+	}
+
+	return helper.ModeratePost(post.Text, post.Hashtags)
+}
+
 func UploadPost() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userClaims, exists := c.Get("user")
@@ -53,11 +79,6 @@ func UploadPost() gin.HandlerFunc {
 			return
 		}
 
-		if len(post.Hashtags) > 3 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "O número máximo de hashtags permitido é 3"})
-			return
-		}
-
 		post.ID = primitive.NewObjectID()
 		post.Name = claims["Name"].(string)
 		post.Role = claims["Role"].(string)
@@ -67,9 +88,8 @@ func UploadPost() gin.HandlerFunc {
 		post.Comments = []model.Comment{}
 		post.CreatedAt = time.Now()
 
-		validationErrors := validate.Struct(post)
-		if validationErrors != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": validationErrors.Error()})
+		if err := checkPostContent(post); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
@@ -93,36 +113,282 @@ func UploadPost() gin.HandlerFunc {
 	}
 }
 
+func feedCursorFilter(value string, descending bool) (bson.M, error) {
+	cursor, err := helper.DecodeCursor(value)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := cursor.Time()
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.KeysetFilter("createdAt", createdAt, cursor.Id, descending), nil
+}
+
 func GetPosts() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		page := c.DefaultQuery("page", "1")
-		pageSize := 5
+		if c.Query("mode") == "ranked" {
+			getRankedPosts(c)
+			return
+		}
 
-		pageInt, err := strconv.Atoi(page)
-		if err != nil || pageInt < 1 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Número da página inválido"})
+		cursorParam := c.Query("cursor")
+		sinceParam := c.Query("since")
+
+		if cursorParam != "" && sinceParam != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Use apenas cursor ou since"})
 			return
 		}
 
-		skip := (pageInt - 1) * pageSize
+		filter := bson.M{}
+		descending := sinceParam == ""
+
+		position := cursorParam
+		if !descending {
+			position = sinceParam
+		}
+
+		if position != "" {
+			var err error
+			filter, err = feedCursorFilter(position, descending)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		limit := helper.ParsePageLimit(c.Query("limit"))
+
+		order := -1
+		if !descending {
+			order = 1
+		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		cursor, err := postCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"createdAt": -1}).SetSkip(int64(skip)).SetLimit(int64(pageSize)))
+		opts := options.Find().
+			SetSort(bson.D{{Key: "createdAt", Value: order}, {Key: "_id", Value: order}}).
+			SetLimit(int64(limit + 1))
+
+		cursor, err := postCollection.Find(ctx, filter, opts)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar posts"})
 			return
 		}
 		defer cursor.Close(ctx)
 
-		var posts []model.Post
+		posts := []model.Post{}
 		if err = cursor.All(ctx, &posts); err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar posts"})
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"posts": posts, "page": pageInt})
+		hasMore := len(posts) > limit
+		if hasMore {
+			posts = posts[:limit]
+		}
+
+		if !descending {
+			for i, j := 0, len(posts)-1; i < j; i, j = i+1, j-1 {
+				posts[i], posts[j] = posts[j], posts[i]
+			}
+		}
+
+		nextCursor := ""
+		if descending && hasMore {
+			last := posts[len(posts)-1]
+			nextCursor = helper.EncodeTimeCursor(last.CreatedAt, last.ID)
+		}
+
+		sinceCursor := sinceParam
+		if len(posts) > 0 {
+			sinceCursor = helper.EncodeTimeCursor(posts[0].CreatedAt, posts[0].ID)
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"posts":       posts,
+			"nextCursor":  nextCursor,
+			"sinceCursor": sinceCursor,
+			"hasMore":     hasMore,
+		})
+	}
+}
+
+func getRankedPosts(c *gin.Context) {
+	if c.Query("since") != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "since não é suportado no feed ranqueado"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	viewer := helper.ViewerFromContext(c)
+	ranker := helper.FeedRankerFor(viewer.Uid, c.Query("ranker"))
+
+	var position *helper.PageCursor
+	var score float64
+	var rankedAt time.Time
+	if cursorParam := c.Query("cursor"); cursorParam != "" {
+		cursor, err := helper.DecodeCursor(cursorParam)
+		if err == nil {
+			score, rankedAt, err = cursor.Score()
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": helper.ErrInvalidCursor.Error()})
+			return
+		}
+		position = &cursor
+	}
+
+	ranked, rankedAt, err := helper.RankFeed(ctx, viewer, ranker, rankedAt)
+	if err == helper.ErrInvalidCursor {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ranquear posts"})
+		return
+	}
+
+	start := 0
+	if position != nil {
+		start = sort.Search(len(ranked), func(i int) bool {
+			return ranked[i].Score < score || (ranked[i].Score == score && ranked[i].Id.Hex() < position.Id.Hex())
+		})
+	}
+
+	limit := helper.ParsePageLimit(c.Query("limit"))
+	end := start + limit
+	if end > len(ranked) {
+		end = len(ranked)
+	}
+	page := ranked[start:end]
+	hasMore := end < len(ranked)
+
+	ids := make([]primitive.ObjectID, 0, len(page))
+	for _, item := range page {
+		ids = append(ids, item.Id)
+	}
+
+	cursor, err := postCollection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar posts"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var found []model.Post
+	if err = cursor.All(ctx, &found); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar posts"})
+		return
+	}
+
+	byId := map[primitive.ObjectID]model.Post{}
+	for _, post := range found {
+		byId[post.ID] = post
+	}
+
+	posts := []model.Post{}
+	for _, item := range page {
+		if post, ok := byId[item.Id]; ok {
+			posts = append(posts, post)
+		}
+	}
+
+	nextCursor := ""
+	if hasMore && len(page) > 0 {
+		last := page[len(page)-1]
+		nextCursor = helper.EncodeScoreCursor(last.Score, rankedAt, last.Id)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"posts":      posts,
+		"nextCursor": nextCursor,
+		"hasMore":    hasMore,
+		"mode":       "ranked",
+		"ranker":     ranker.Name(),
+	})
+}
+
+func PinPost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postId, err := primitive.ObjectIDFromHex(c.Param("postId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID do post inválido"})
+			return
+		}
+
+		request := struct {
+			Boost float64 `json:"boost" validate:"gt=0,lte=100"`
+			Hours int     `json:"hours" validate:"gt=0,lte=720"`
+		}{Boost: 10, Hours: 24}
+
+		if err := c.ShouldBindJSON(&request); err != nil && c.Request.ContentLength > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "erro ao ler dados"})
+			return
+		}
+
+		if err := validate.Struct(request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		pinnedUntil := time.Now().Add(time.Duration(request.Hours) * time.Hour)
+
+		result, err := postCollection.UpdateOne(ctx, bson.M{"_id": postId}, bson.M{"$set": bson.M{
+			"pinnedBoost": request.Boost,
+			"pinnedUntil": pinnedUntil,
+		}})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao fixar post"})
+			return
+		}
+
+		if result.MatchedCount == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post não encontrado"})
+			return
+		}
+
+		helper.InvalidateFeedRanking()
+		helper.Audit(c, helper.AuditPostPin, "post", postId.Hex(), nil, bson.M{"pinnedBoost": request.Boost, "pinnedUntil": pinnedUntil})
+
+		c.JSON(http.StatusOK, gin.H{"message": "Post fixado com sucesso", "pinnedBoost": request.Boost, "pinnedUntil": pinnedUntil})
+	}
+}
+
+func UnpinPost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postId, err := primitive.ObjectIDFromHex(c.Param("postId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID do post inválido"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		result, err := postCollection.UpdateOne(ctx, bson.M{"_id": postId}, bson.M{"$unset": bson.M{"pinnedBoost": "", "pinnedUntil": ""}})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao desafixar post"})
+			return
+		}
+
+		if result.MatchedCount == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post não encontrado"})
+			return
+		}
+
+		helper.InvalidateFeedRanking()
+		helper.Audit(c, helper.AuditPostUnpin, "post", postId.Hex(), nil, nil)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Post desafixado com sucesso"})
 	}
 }
 
@@ -165,7 +431,6 @@ func DeletePost() gin.HandlerFunc {
 			return
 		}
 
-		userType := claims["UserType"].(string)
 		userId := claims["Uid"].(string)
 
 		postIdParam := c.Param("postId")
@@ -187,7 +452,7 @@ func DeletePost() gin.HandlerFunc {
 			return
 		}
 
-		if post.OwnerId != userId && userType != "ADMIN" {
+		if post.OwnerId != userId && !helper.HasClaimsPermission(claims, helper.PermPostModerate) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Você não tem permissão para deletar este post"})
 			return
 		}
@@ -198,6 +463,10 @@ func DeletePost() gin.HandlerFunc {
 			return
 		}
 
+		if _, err := postRevisionCollection.DeleteMany(ctx, bson.M{"postId": postId}); err != nil {
+			log.Printf("Erro ao remover revisões do post %s: %v\n", postId.Hex(), err)
+		}
+
 		c.JSON(http.StatusOK, gin.H{"message": "Post deletado com sucesso"})
 	}
 }
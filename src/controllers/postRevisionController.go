@@ -0,0 +1,254 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"reflect"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var postRevisionCollection *mongo.Collection = database.OpenCollection(database.Client, "postRevisions")
+
+var errPostConflict = errors.New("o post foi alterado por outra requisição, tente novamente")
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := postRevisionCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "postId", Value: 1}, {Key: "version", Value: -1}},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de revisões de posts: %v\n", err)
+	}
+}
+
+func postVersionFilter(post model.Post) bson.M {
+	if post.Version == 0 {
+		return bson.M{"_id": post.ID, "version": bson.M{"$exists": false}}
+	}
+	return bson.M{"_id": post.ID, "version": post.Version}
+}
+
+func replacePostContent(ctx context.Context, current model.Post, next model.Post, replacedBy string, reason string) (model.Post, error) {
+	now := time.Now()
+
+	writtenAt := current.CreatedAt
+	if current.EditedAt != nil {
+		writtenAt = *current.EditedAt
+	}
+
+	revision := model.PostRevision{
+		ID:         primitive.NewObjectID(),
+		PostId:     current.ID,
+		Version:    current.Version,
+		Text:       current.Text,
+		Hashtags:   current.Hashtags,
+		ImageUrl:   current.ImageUrl,
+		WrittenAt:  writtenAt,
+		ReplacedBy: replacedBy,
+		ReplacedAt: now,
+		Reason:     reason,
+	}
+
+	if _, err := postRevisionCollection.InsertOne(ctx, revision); err != nil {
+		return model.Post{}, err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"text":     next.Text,
+			"hashtags": next.Hashtags,
+			"imageUrl": next.ImageUrl,
+			"editedAt": now,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := postCollection.UpdateOne(ctx, postVersionFilter(current), update)
+	if err == nil && result.MatchedCount == 0 {
+		err = errPostConflict
+	}
+	if err != nil {
+		if _, deleteErr := postRevisionCollection.DeleteOne(ctx, bson.M{"_id": revision.ID}); deleteErr != nil {
+			log.Printf("Erro ao descartar revisão %s: %v\n", revision.ID.Hex(), deleteErr)
+		}
+		return model.Post{}, err
+	}
+
+	next.Version = current.Version + 1
+	next.EditedAt = &now
+	return next, nil
+}
+
+func EditPost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId := helper.GetClaim(c, "Uid")
+		if userId == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			return
+		}
+
+		postId, err := primitive.ObjectIDFromHex(c.Param("postId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID do post inválido"})
+			return
+		}
+
+		var request model.PostEdit
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "erro ao ler dados"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var post model.Post
+		if err := postCollection.FindOne(ctx, bson.M{"_id": postId}).Decode(&post); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post não encontrado"})
+			return
+		}
+
+		if post.OwnerId != userId {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Você só pode editar seus próprios posts"})
+			return
+		}
+
+		edited := post
+		if request.Text != nil {
+			edited.Text = *request.Text
+		}
+		if request.Hashtags != nil {
+			edited.Hashtags = *request.Hashtags
+		}
+		if request.ImageUrl != nil {
+			edited.ImageUrl = *request.ImageUrl
+		}
+
+		if edited.Text == post.Text && edited.ImageUrl == post.ImageUrl && reflect.DeepEqual(edited.Hashtags, post.Hashtags) {
+			c.JSON(http.StatusOK, gin.H{"message": "Nenhuma alteração no post", "post": post})
+			return
+		}
+
+		if err := checkPostContent(edited); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		updated, err := replacePostContent(ctx, post, edited, userId, "edit")
+		if err == errPostConflict {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao editar post"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Post editado com sucesso", "post": updated})
+	}
+}
+
+func GetPostRevisions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postId, err := primitive.ObjectIDFromHex(c.Param("postId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID do post inválido"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var post model.Post
+		if err := postCollection.FindOne(ctx, bson.M{"_id": postId}).Decode(&post); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post não encontrado"})
+			return
+		}
+
+		cursor, err := postRevisionCollection.Find(ctx, bson.M{"postId": postId}, options.Find().SetSort(bson.M{"version": -1}))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar revisões"})
+			return
+		}
+		defer cursor.Close(ctx)
+
+		revisions := []model.PostRevision{}
+		if err := cursor.All(ctx, &revisions); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar revisões"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"post": post, "revisions": revisions})
+	}
+}
+
+func RestorePostRevision() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postId, err := primitive.ObjectIDFromHex(c.Param("postId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID do post inválido"})
+			return
+		}
+
+		revisionId, err := primitive.ObjectIDFromHex(c.Param("revisionId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID da revisão inválido"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var revision model.PostRevision
+		if err := postRevisionCollection.FindOne(ctx, bson.M{"_id": revisionId, "postId": postId}).Decode(&revision); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Revisão não encontrada"})
+			return
+		}
+
+		var post model.Post
+		if err := postCollection.FindOne(ctx, bson.M{"_id": postId}).Decode(&post); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post não encontrado"})
+			return
+		}
+
+		restored := post
+		restored.Text = revision.Text
+		restored.Hashtags = revision.Hashtags
+		restored.ImageUrl = revision.ImageUrl
+
+		if err := checkPostContent(restored); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		updated, err := replacePostContent(ctx, post, restored, helper.GetClaim(c, "Uid"), "restore")
+		if err == errPostConflict {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao restaurar revisão"})
+			return
+		}
+
+		helper.Audit(c, helper.AuditPostRestore, "post", postId.Hex(),
+			bson.M{"text": post.Text, "hashtags": post.Hashtags, "imageUrl": post.ImageUrl},
+			bson.M{"text": updated.Text, "hashtags": updated.Hashtags, "imageUrl": updated.ImageUrl},
+		)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Revisão restaurada com sucesso", "post": updated})
+	}
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func GetPrivacySettings() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		user, ok := findCurrentUser(c, ctx)
+		if !ok {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"privacy": user.Privacy.WithDefaults()})
+	}
+}
+
+func UpdatePrivacySettings() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request model.PrivacySettings
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+			return
+		}
+
+		validationErrors := validate.Struct(request)
+		if validationErrors != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErrors.Error()})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		user, ok := findCurrentUser(c, ctx)
+		if !ok {
+			return
+		}
+
+		privacy := user.Privacy.WithDefaults()
+		if request.Email != "" {
+			privacy.Email = request.Email
+		}
+		if request.Phone != "" {
+			privacy.Phone = request.Phone
+		}
+		if request.BirthdayYear != "" {
+			privacy.BirthdayYear = request.BirthdayYear
+		}
+		if request.Socials != "" {
+			privacy.Socials = request.Socials
+		}
+
+		_, err := userCollection.UpdateOne(ctx, bson.M{"uid": user.Uid}, bson.M{"$set": bson.M{"privacy": privacy}})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar configurações de privacidade"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Configurações de privacidade atualizadas com sucesso", "privacy": privacy})
+	}
+}
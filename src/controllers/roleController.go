@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var roleCollection *mongo.Collection = database.OpenCollection(database.Client, "roles")
+
+func validatePermissions(permissions []string) (string, bool) {
+	for _, permission := range permissions {
+		if !helper.IsKnownPermission(permission) {
+			return permission, false
+		}
+	}
+	return "", true
+}
+
+func GetPermissions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"permissions": helper.Permissions})
+	}
+}
+
+func GetRoles() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		cursor, err := roleCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"name": 1}))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar cargos"})
+			return
+		}
+		defer cursor.Close(ctx)
+
+		roles := []model.Role{}
+		if err = cursor.All(ctx, &roles); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar cargos"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"roles": roles})
+	}
+}
+
+func CreateRole() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var role model.Role
+
+		if err := c.ShouldBindJSON(&role); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+			return
+		}
+
+		role.Name = strings.ToUpper(strings.TrimSpace(role.Name))
+		role.ID = primitive.NewObjectID()
+		role.CreatedAt = time.Now()
+		role.UpdatedAt = role.CreatedAt
+		if role.Permissions == nil {
+			role.Permissions = []string{}
+		}
+
+		validationErrors := validate.Struct(role)
+		if validationErrors != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErrors.Error()})
+			return
+		}
+
+		if permission, ok := validatePermissions(role.Permissions); !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Permissão desconhecida", "permission": permission})
+			return
+		}
+
+		if role.Name == helper.ServiceUserType {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Nome de cargo reservado"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := roleCollection.InsertOne(ctx, role)
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Cargo já existe"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar cargo"})
+			return
+		}
+
+		helper.InvalidateRoleCache()
+		helper.Audit(c, helper.AuditRoleCreate, "role", role.Name, nil, role)
+
+		c.JSON(http.StatusCreated, gin.H{"message": "Cargo criado com sucesso", "role": role})
+	}
+}
+
+func UpdateRole() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := strings.ToUpper(c.Param("name"))
+
+		var request struct {
+			Description *string  `json:"description"`
+			Permissions []string `json:"permissions"`
+			RequireMfa  *bool    `json:"requireMfa"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+			return
+		}
+
+		update := bson.M{"updatedAt": time.Now()}
+
+		if request.Description != nil {
+			update["description"] = *request.Description
+		}
+
+		if request.RequireMfa != nil {
+			update["requireMfa"] = *request.RequireMfa
+		}
+
+		if request.Permissions != nil {
+			if permission, ok := validatePermissions(request.Permissions); !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Permissão desconhecida", "permission": permission})
+				return
+			}
+
+			if name == "ADMIN" && !contains(request.Permissions, "*") {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "O cargo ADMIN deve manter acesso total"})
+				return
+			}
+
+			update["permissions"] = request.Permissions
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var previous model.Role
+		if err := roleCollection.FindOne(ctx, bson.M{"name": name}).Decode(&previous); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cargo não encontrado"})
+			return
+		}
+
+		var role model.Role
+		err := roleCollection.FindOneAndUpdate(
+			ctx,
+			bson.M{"name": name},
+			bson.M{"$set": update},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&role)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cargo não encontrado"})
+			return
+		}
+
+		helper.InvalidateRoleCache()
+		helper.Audit(c, helper.AuditRoleUpdate, "role", role.Name, previous, role)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Cargo atualizado com sucesso", "role": role})
+	}
+}
+
+func DeleteRole() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := strings.ToUpper(c.Param("name"))
+
+		if name == "ADMIN" || name == "USER" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Não é possível remover um cargo padrão"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		count, err := userCollection.CountDocuments(ctx, bson.M{"userType": name})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao verificar usuários do cargo"})
+			return
+		}
+
+		if count > 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "Cargo possui usuários associados", "users": count})
+			return
+		}
+
+		var role model.Role
+		err = roleCollection.FindOneAndDelete(ctx, bson.M{"name": name}).Decode(&role)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cargo não encontrado"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover cargo"})
+			return
+		}
+
+		helper.InvalidateRoleCache()
+		helper.Audit(c, helper.AuditRoleDelete, "role", role.Name, role, nil)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Cargo removido com sucesso"})
+	}
+}
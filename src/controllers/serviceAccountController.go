@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var serviceAccountCollection *mongo.Collection = database.OpenCollection(database.Client, "serviceAccounts")
+var apiKeyCollection *mongo.Collection = database.OpenCollection(database.Client, "apiKeys")
+
+func CreateServiceAccount() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaims, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			return
+		}
+
+		claims, ok := userClaims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
+			return
+		}
+
+		var account model.ServiceAccount
+
+		if err := c.ShouldBindJSON(&account); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+			return
+		}
+
+		account.ID = primitive.NewObjectID()
+		account.Uid = account.ID.Hex()
+		account.Disabled = false
+		account.CreatedBy = claims["Uid"].(string)
+		account.CreatedAt = time.Now()
+
+		validationErrors := validate.Struct(account)
+		if validationErrors != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErrors.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := serviceAccountCollection.InsertOne(ctx, account)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar conta de serviço"})
+			return
+		}
+
+		helper.Audit(c, helper.AuditServiceAccountCreate, "serviceAccount", account.Uid, nil, account)
+
+		c.JSON(http.StatusCreated, gin.H{"message": "Conta de serviço criada com sucesso", "serviceAccount": account})
+	}
+}
+
+func GetServiceAccounts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		cursor, err := serviceAccountCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"createdAt": -1}))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar contas de serviço"})
+			return
+		}
+		defer cursor.Close(ctx)
+
+		accounts := []model.ServiceAccount{}
+		if err = cursor.All(ctx, &accounts); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar contas de serviço"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"serviceAccounts": accounts})
+	}
+}
+
+func DisableServiceAccount() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accountId := c.Param("accountId")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		result, err := serviceAccountCollection.UpdateOne(ctx, bson.M{"uid": accountId}, bson.M{"$set": bson.M{"disabled": true}})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao desativar conta de serviço"})
+			return
+		}
+
+		if result.MatchedCount == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Conta de serviço não encontrada"})
+			return
+		}
+
+		revoked, err := apiKeyCollection.UpdateMany(
+			ctx,
+			bson.M{"serviceAccountId": accountId, "revokedAt": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"revokedAt": time.Now()}},
+		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao revogar chaves da conta de serviço"})
+			return
+		}
+
+		helper.Audit(c, helper.AuditServiceAccountDisable, "serviceAccount", accountId,
+			bson.M{"disabled": result.ModifiedCount == 0},
+			bson.M{"disabled": true, "revokedKeys": revoked.ModifiedCount},
+		)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Conta de serviço desativada com sucesso"})
+	}
+}
+
+func CreateApiKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaims, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			return
+		}
+
+		claims, ok := userClaims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
+			return
+		}
+
+		accountId := c.Param("accountId")
+
+		var request struct {
+			Name          string   `json:"name" validate:"required"`
+			Scopes        []string `json:"scopes" validate:"required,min=1"`
+			ExpiresInDays int      `json:"expiresInDays" validate:"gte=0"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+			return
+		}
+
+		validationErrors := validate.Struct(request)
+		if validationErrors != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErrors.Error()})
+			return
+		}
+
+		for _, scope := range request.Scopes {
+			if scope == "*" || !helper.IsKnownPermission(scope) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Escopo inválido", "scope": scope})
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		count, err := serviceAccountCollection.CountDocuments(ctx, bson.M{"uid": accountId, "disabled": false})
+		if err != nil || count == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Conta de serviço não encontrada"})
+			return
+		}
+
+		key, prefix, hash := helper.GenerateApiKey()
+
+		apiKey := model.ApiKey{
+			ID:               primitive.NewObjectID(),
+			ServiceAccountId: accountId,
+			Name:             request.Name,
+			Prefix:           prefix,
+			KeyHash:          hash,
+			Scopes:           request.Scopes,
+			CreatedBy:        claims["Uid"].(string),
+			CreatedAt:        time.Now(),
+		}
+
+		if request.ExpiresInDays > 0 {
+			expiresAt := apiKey.CreatedAt.AddDate(0, 0, request.ExpiresInDays)
+			apiKey.ExpiresAt = &expiresAt
+		}
+
+		_, err = apiKeyCollection.InsertOne(ctx, apiKey)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar chave de API"})
+			return
+		}
+
+		helper.Audit(c, helper.AuditApiKeyCreate, "apiKey", apiKey.ID.Hex(), nil, apiKey)
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Chave criada com sucesso. Guarde-a, ela não será exibida novamente",
+			"key":     key,
+			"apiKey":  apiKey,
+		})
+	}
+}
+
+func GetApiKeys() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accountId := c.Param("accountId")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		cursor, err := apiKeyCollection.Find(ctx, bson.M{"serviceAccountId": accountId}, options.Find().SetSort(bson.M{"createdAt": -1}))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar chaves de API"})
+			return
+		}
+		defer cursor.Close(ctx)
+
+		apiKeys := []model.ApiKey{}
+		if err = cursor.All(ctx, &apiKeys); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar chaves de API"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"apiKeys": apiKeys})
+	}
+}
+
+func RevokeApiKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accountId := c.Param("accountId")
+
+		keyId, err := primitive.ObjectIDFromHex(c.Param("keyId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID da chave inválido"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		result, err := apiKeyCollection.UpdateOne(
+			ctx,
+			bson.M{"_id": keyId, "serviceAccountId": accountId, "revokedAt": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"revokedAt": time.Now()}},
+		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao revogar chave de API"})
+			return
+		}
+
+		if result.MatchedCount == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Chave de API não encontrada ou já revogada"})
+			return
+		}
+
+		helper.Audit(c, helper.AuditApiKeyRevoke, "apiKey", keyId.Hex(), nil, bson.M{"serviceAccountId": accountId, "revoked": true})
+
+		c.JSON(http.StatusOK, gin.H{"message": "Chave de API revogada com sucesso"})
+	}
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"net/http"
+
+	helper "github.com/Nooksd/go-server/src/helpers"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func Logout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaims, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			return
+		}
+
+		claims, ok := userClaims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
+			return
+		}
+
+		sessionId, _ := claims["SessionId"].(string)
+		if sessionId == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Token sem sessão associada"})
+			return
+		}
+
+		if err := helper.RevokeSession(sessionId, "logout"); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Logout realizado com sucesso"})
+	}
+}
+
+func LogoutAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaims, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			return
+		}
+
+		claims, ok := userClaims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
+			return
+		}
+
+		userId := claims["Uid"].(string)
+		targetUserId := c.DefaultQuery("userId", userId)
+
+		if targetUserId != userId && !helper.HasClaimsPermission(claims, helper.PermSessionManage) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário sem permissão"})
+			return
+		}
+
+		revoked, err := helper.RevokeUserSessions(targetUserId, "", "logout-all")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao encerrar sessões"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Sessões encerradas com sucesso", "revoked": revoked})
+	}
+}
+
+func GetSessions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaims, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			return
+		}
+
+		claims, ok := userClaims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
+			return
+		}
+
+		userId := claims["Uid"].(string)
+		targetUserId := c.DefaultQuery("userId", userId)
+
+		if targetUserId != userId && !helper.HasClaimsPermission(claims, helper.PermSessionManage) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário sem permissão"})
+			return
+		}
+
+		sessions, err := helper.GetUserSessions(targetUserId)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar sessões"})
+			return
+		}
+
+		currentSessionId, _ := claims["SessionId"].(string)
+
+		c.JSON(http.StatusOK, gin.H{"sessions": sessions, "currentSessionId": currentSessionId})
+	}
+}
+
+func DeleteSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaims, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			return
+		}
+
+		claims, ok := userClaims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
+			return
+		}
+
+		sessionId := c.Param("sessionId")
+
+		session, err := helper.GetSession(sessionId)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		if session.UserId != claims["Uid"].(string) && !helper.HasClaimsPermission(claims, helper.PermSessionManage) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário sem permissão"})
+			return
+		}
+
+		if err := helper.RevokeSession(sessionId, "revoked"); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Sessão encerrada com sucesso"})
+	}
+}
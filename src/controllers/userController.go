@@ -2,9 +2,17 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	database "github.com/Nooksd/go-server/src/db"
@@ -16,12 +24,59 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"golang.org/x/crypto/bcrypt"
 )
 
 var userCollection *mongo.Collection = database.OpenCollection(database.Client, "users")
+var passwordResetCollection *mongo.Collection = database.OpenCollection(database.Client, "passwordResets")
 var validate = validator.New()
 
+var passwordResetDuration = time.Hour
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9 ()\-]{8,20}$`)
+
+func init() {
+	validate.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		return phonePattern.MatchString(fl.Field().String())
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := passwordResetCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.M{"tokenHash": 1}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.M{"expiresAt": 1}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de redefinição de senha: %v\n", err)
+	}
+
+	if err := createUserSearchIndex(ctx); err != nil {
+		log.Printf("Erro ao criar índice de busca de usuários (execute \"migrate\" se o índice antigo ainda existir): %v\n", err)
+	}
+}
+
+func createUserSearchIndex(ctx context.Context) error {
+	_, err := userCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "name", Value: "text"}, {Key: "role", Value: "text"}},
+		Options: options.Index().
+			SetName("user_directory_search").
+			SetWeights(bson.M{"name": 10, "role": 5}).
+			SetDefaultLanguage("portuguese"),
+	})
+	return err
+}
+
+func MigrateUserSearchIndex(ctx context.Context) error {
+	_, err := userCollection.Indexes().DropOne(ctx, "user_directory_text")
+	var commandErr mongo.CommandError
+	if err != nil && !(errors.As(err, &commandErr) && commandErr.Code == 27) {
+		return err
+	}
+	return createUserSearchIndex(ctx)
+}
+
 func HashPassword(password string) string {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), 14)
 	if err != nil {
@@ -39,27 +94,50 @@ func VerifyPassword(providedPassword string, storedHash string) error {
 	return nil
 }
 
-func CreateUser() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		userClaims, exists := c.Get("user")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
-			return
-		}
+func setUserPassword(ctx context.Context, user model.User, password string) error {
+	update := bson.M{"$set": bson.M{"password": HashPassword(password)}}
 
-		claims, ok := userClaims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
-			return
+	if user.Password != nil && helper.DefaultPasswordPolicy.HistorySize > 0 {
+		update["$push"] = bson.M{
+			"passwordHistory": bson.M{
+				"$each":     []string{*user.Password},
+				"$position": 0,
+				"$slice":    helper.DefaultPasswordPolicy.HistorySize,
+			},
 		}
+	}
 
-		userType := claims["UserType"].(string)
+	_, err := userCollection.UpdateOne(ctx, bson.M{"uid": user.Uid}, update)
+	return err
+}
 
-		if userType != "ADMIN" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário sem permissão", "tipo": userType})
-			return
-		}
-		
+func applyUserDefaults(user *model.User) {
+	defaultRole := "Membro"
+	emptyString := ""
+	defaultPoints := 0
+	active := true
+
+	user.ProfilePictureUrl = "http://192.168.1.68:9000/avatar/get/avatar1"
+	user.PhoneNumber = &emptyString
+	user.Role = &defaultRole
+	user.EntryDate = helper.CalendarDate(helper.CompanyNow())
+	if !user.Birthday.IsZero() {
+		user.Birthday = helper.CalendarDate(user.Birthday)
+	}
+	user.LinkedinURL = &emptyString
+	user.FacebookURL = &emptyString
+	user.InstagramURL = &emptyString
+	user.PTotal = &defaultPoints
+	user.PSpent = &defaultPoints
+	user.PCurrent = &defaultPoints
+	user.Active = &active
+
+	user.ID = primitive.NewObjectID()
+	user.Uid = user.ID.Hex()
+}
+
+func CreateUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
 		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
 		defer cancel()
 
@@ -70,12 +148,36 @@ func CreateUser() gin.HandlerFunc {
 			return
 		}
 
-		validationErrors := validate.Struct(user)
+		invite := c.Query("mode") == "invite" || user.Password == nil
+
+		var validationErrors error
+		if invite {
+			validationErrors = validate.StructExcept(user, "Password")
+		} else {
+			validationErrors = validate.Struct(user)
+		}
 		if validationErrors != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": validationErrors.Error()})
 			return
 		}
 
+		if !invite {
+			if err := helper.DefaultPasswordPolicy.Validate(*user.Password, user); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		if !helper.RoleExists(*user.UserType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "tipo de usuário inválido"})
+			return
+		}
+
+		if err := helper.CheckRoleAssignment(c, *user.UserType); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
 		count, err := userCollection.CountDocuments(ctx, bson.M{"email": user.Email})
 		if err != nil {
 			log.Panic(err)
@@ -88,27 +190,16 @@ func CreateUser() gin.HandlerFunc {
 			return
 		}
 
-		password := HashPassword(*user.Password)
-		user.Password = &password
-
-		defaultRole := "Membro"
-		emptyString := ""
-		defaultPoints := 0
-
-		user.ProfilePictureUrl = "http://192.168.1.68:9000/avatar/get/avatar1"
-		user.PhoneNumber = &emptyString
-		user.Role = &defaultRole
-		user.EntryDate = time.Now()
-		user.Birthday = time.Now()
-		user.LinkedinURL = &emptyString
-		user.FacebookURL = &emptyString
-		user.InstagramURL = &emptyString
-		user.PTotal = &defaultPoints
-		user.PSpent = &defaultPoints
-		user.PCurrent = &defaultPoints
+		if invite {
+			password := HashPassword(helper.NewTokenId())
+			user.Password = &password
+			user.Pending = true
+		} else {
+			password := HashPassword(*user.Password)
+			user.Password = &password
+		}
 
-		user.ID = primitive.NewObjectID()
-		user.Uid = user.ID.Hex()
+		applyUserDefaults(&user)
 
 		resultInsertionNumber, insertErr := userCollection.InsertOne(ctx, user)
 		if insertErr != nil {
@@ -117,6 +208,16 @@ func CreateUser() gin.HandlerFunc {
 		}
 		defer cancel()
 
+		helper.Audit(c, helper.AuditUserCreate, "user", user.Uid, nil, user)
+
+		var invitation gin.H
+		if invite {
+			invitation, err = inviteUser(ctx, c, user)
+			if err != nil {
+				log.Printf("Erro ao gerar convite para %s: %v\n", *user.Email, err)
+			}
+		}
+
 		helper.CreateNotification(
 			fmt.Sprintf(
 				"Novo funcionário adicionado: %s",
@@ -124,11 +225,27 @@ func CreateUser() gin.HandlerFunc {
 			),
 			"contact")
 
+		if invite {
+			c.JSON(http.StatusCreated, gin.H{
+				"InsertedID": resultInsertionNumber.InsertedID,
+				"uid":        user.Uid,
+				"pending":    true,
+				"invitation": invitation,
+			})
+			return
+		}
+
 		c.JSON(http.StatusCreated, resultInsertionNumber)
 
 	}
 }
 
+func respondLoginLocked(c *gin.Context, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": "muitas tentativas de login, tente novamente mais tarde", "retryAfter": seconds})
+}
+
 func LoginUser() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
@@ -146,26 +263,111 @@ func LoginUser() gin.HandlerFunc {
 			return
 		}
 
+		if retryAfter := helper.CheckLoginAllowed(*user.Email, c.ClientIP()); retryAfter > 0 {
+			respondLoginLocked(c, retryAfter)
+			return
+		}
+
 		err := userCollection.FindOne(ctx, bson.M{"email": user.Email}).Decode(&foundUser)
 		if err != nil {
+			if retryAfter := helper.RegisterLoginFailure(*user.Email, c.ClientIP(), ""); retryAfter > 0 {
+				respondLoginLocked(c, retryAfter)
+				return
+			}
 			c.JSON(http.StatusNotFound, gin.H{"error": "email e/ou senha incorretos"})
 			return
 		}
 
-		if err := VerifyPassword(*user.Password, *foundUser.Password); err != nil {
+		if foundUser.Password == nil || VerifyPassword(*user.Password, *foundUser.Password) != nil {
+			if retryAfter := helper.RegisterLoginFailure(*user.Email, c.ClientIP(), foundUser.Uid); retryAfter > 0 {
+				respondLoginLocked(c, retryAfter)
+				return
+			}
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "email e/ou senha incorretos"})
 			return
 		}
 
-		accessToken, refreshToken, _ := helper.GenerateTokens(*foundUser.Email, *foundUser.Name, foundUser.ProfilePictureUrl, *foundUser.Role, foundUser.Uid, *foundUser.UserType, true)
+		if !foundUser.IsActive() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Usuário desativado"})
+			return
+		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"accessToken":  accessToken,
-			"refreshToken": refreshToken,
-			"user":         foundUser,
-			"type":         foundUser.UserType,
-		})
+		if foundUser.Pending {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Conta pendente de ativação, verifique seu convite"})
+			return
+		}
+
+		completeLogin(c, foundUser, gin.H{})
+	}
+}
+
+func completeLogin(c *gin.Context, user model.User, extra gin.H) {
+	if !user.IsActive() || user.Pending {
+		issueLoginTokens(c, user, extra)
+		return
+	}
+
+	if user.MfaEnabled || helper.RoleRequiresMfa(*user.UserType) {
+		mfaToken, err := helper.GenerateMfaToken(user.Uid)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar token de verificação"})
+			return
+		}
+
+		response := gin.H{
+			"mfaRequired":      true,
+			"mfaSetupRequired": !user.MfaEnabled,
+			"mfaToken":         mfaToken,
+		}
+		for key, value := range extra {
+			response[key] = value
+		}
+
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	issueLoginTokens(c, user, extra)
+}
+
+func issueLoginTokens(c *gin.Context, user model.User, extra gin.H) {
+	if !user.IsActive() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Usuário desativado"})
+		return
+	}
+
+	if user.Pending {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Conta pendente de ativação, verifique seu convite"})
+		return
+	}
+
+	helper.ResetLoginFailures(*user.Email)
+
+	session, err := helper.CreateSession(user.Uid, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar sessão"})
+		return
+	}
+
+	accessToken, refreshToken, err := helper.GenerateTokens(*user.Email, *user.Name, user.ProfilePictureUrl, *user.Role, user.Uid, *user.UserType, session.ID.Hex(), session.TokenId, true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar tokens"})
+		return
+	}
+
+	helper.ApplyPrivacy(&user, helper.Viewer{Uid: user.Uid})
+
+	response := gin.H{
+		"accessToken":  accessToken,
+		"refreshToken": refreshToken,
+		"user":         user,
+		"type":         user.UserType,
 	}
+	for key, value := range extra {
+		response[key] = value
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 func GetCurrentUser() gin.HandlerFunc {
@@ -195,6 +397,8 @@ func GetCurrentUser() gin.HandlerFunc {
 			return
 		}
 
+		helper.ApplyPrivacy(&user, helper.ViewerFromContext(c))
+
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Sucesso",
 			"user":    user,
@@ -210,9 +414,7 @@ func RefreshToken() gin.HandlerFunc {
 			return
 		}
 
-		token, err := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
-			return []byte(helper.SECRET_KEY), nil
-		})
+		token, err := helper.ParseToken(refreshToken, jwt.MapClaims{})
 
 		if err != nil || !token.Valid {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Token inválido"})
@@ -225,25 +427,110 @@ func RefreshToken() gin.HandlerFunc {
 			return
 		}
 
-		email := claims["Email"].(string)
-		name := claims["Name"].(string)
-		profilePictureUrl := claims["ProfilePictureUrl"].(string)
-		role := claims["Role"].(string)
-		uid := claims["Uid"].(string)
-		userType := claims["UserType"].(string)
+		if !helper.IsTokenType(claims, helper.RefreshTokenType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Token inválido"})
+			return
+		}
+
+		sessionId, _ := claims["SessionId"].(string)
+		tokenId, _ := claims["jti"].(string)
+
+		if sessionId == "" || tokenId == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Sessão inválida, faça login novamente"})
+			return
+		}
+
+		session, err := helper.RotateSession(sessionId, tokenId, c.ClientIP())
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		var user model.User
+		err = userCollection.FindOne(ctx, bson.M{"uid": session.UserId}).Decode(&user)
+		if err != nil {
+			helper.RevokeSession(sessionId, "user-not-found")
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+
+		if !user.IsActive() {
+			helper.RevokeSession(sessionId, "deactivated")
+			c.JSON(http.StatusForbidden, gin.H{"error": "Usuário desativado"})
+			return
+		}
 
-		newAccessToken, _, err := helper.GenerateTokens(email, name, profilePictureUrl, role, uid, userType, false)
+		newAccessToken, newRefreshToken, err := helper.GenerateTokens(*user.Email, *user.Name, user.ProfilePictureUrl, *user.Role, user.Uid, *user.UserType, sessionId, session.TokenId, true)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar novo token"})
 			return
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"accessToken": newAccessToken,
+			"accessToken":  newAccessToken,
+			"refreshToken": newRefreshToken,
 		})
 	}
 }
 
+func setIfChanged(updates bson.M, changed *[]string, field string, current *string, value *string) {
+	if value == nil {
+		return
+	}
+	if current != nil && *current == *value {
+		return
+	}
+	updates[field] = *value
+	*changed = append(*changed, field)
+}
+
+func setDateIfChanged(updates bson.M, changed *[]string, field string, current time.Time, value *time.Time) {
+	if value == nil {
+		return
+	}
+	date := helper.CalendarDate(*value)
+	if current.Equal(date) {
+		return
+	}
+	updates[field] = date
+	*changed = append(*changed, field)
+}
+
+func buildUserUpdate(request model.UserAdminUpdate, current model.User) (bson.M, []string) {
+	updates := bson.M{}
+	changed := []string{}
+
+	profilePictureUrl := current.ProfilePictureUrl
+	setIfChanged(updates, &changed, "profilePictureUrl", &profilePictureUrl, request.ProfilePictureUrl)
+	setIfChanged(updates, &changed, "phoneNumber", current.PhoneNumber, request.PhoneNumber)
+	setDateIfChanged(updates, &changed, "birthday", current.Birthday, request.Birthday)
+	setIfChanged(updates, &changed, "linkedinUrl", current.LinkedinURL, request.LinkedinURL)
+	setIfChanged(updates, &changed, "facebookUrl", current.FacebookURL, request.FacebookURL)
+	setIfChanged(updates, &changed, "instagramUrl", current.InstagramURL, request.InstagramURL)
+
+	setIfChanged(updates, &changed, "name", current.Name, request.Name)
+	setIfChanged(updates, &changed, "email", current.Email, request.Email)
+	setIfChanged(updates, &changed, "role", current.Role, request.Role)
+	setDateIfChanged(updates, &changed, "entryDate", current.EntryDate, request.EntryDate)
+	setIfChanged(updates, &changed, "userType", current.UserType, request.UserType)
+	setIfChanged(updates, &changed, "departmentId", &current.DepartmentId, request.DepartmentId)
+	setIfChanged(updates, &changed, "managerId", &current.ManagerId, request.ManagerId)
+
+	return updates, changed
+}
+
+func changesAdminFields(changed []string) bool {
+	for _, field := range changed {
+		if !contains(model.UserProfileFields, field) {
+			return true
+		}
+	}
+	return false
+}
+
 func UpdateOneUser() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userClaims, exists := c.Get("user")
@@ -258,42 +545,142 @@ func UpdateOneUser() gin.HandlerFunc {
 			return
 		}
 
-		userType := claims["UserType"].(string)
 		userId := claims["Uid"].(string)
 		targetUserId := c.Param("userId")
+		isAdmin := helper.HasClaimsPermission(claims, helper.PermUserUpdate)
 
-		if userType != "ADMIN" && userId != targetUserId {
+		if userId != targetUserId && !isAdmin {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Você não tem permissão para atualizar este usuário"})
 			return
 		}
 
-		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
-		defer cancel()
+		body, err := c.GetRawData()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao ler os dados de atualização"})
+			return
+		}
 
-		var userUpdates bson.M
-		if err := c.BindJSON(&userUpdates); err != nil {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao ler os dados de atualização"})
 			return
 		}
 
-		delete(userUpdates, "userType")
-		delete(userUpdates, "password")
-		delete(userUpdates, "uid")
+		var unknownFields, forbiddenFields []string
+		for field := range fields {
+			switch {
+			case contains(model.UserProfileFields, field):
+			case contains(model.UserAdminFields, field):
+				if !isAdmin {
+					forbiddenFields = append(forbiddenFields, field)
+				}
+			case contains(model.UserRoleFields, field):
+				if !helper.HasClaimsPermission(claims, helper.PermRoleManage) {
+					forbiddenFields = append(forbiddenFields, field)
+				}
+			default:
+				unknownFields = append(unknownFields, field)
+			}
+		}
 
-		filter := bson.M{"uid": targetUserId}
-		update := bson.M{"$set": userUpdates}
+		if len(unknownFields) > 0 {
+			sort.Strings(unknownFields)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Campos não permitidos", "fields": unknownFields})
+			return
+		}
 
-		result, err := userCollection.UpdateOne(ctx, filter, update)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar os dados do usuário"})
+		if len(forbiddenFields) > 0 {
+			sort.Strings(forbiddenFields)
+			c.JSON(http.StatusForbidden, gin.H{"error": "Você não tem permissão para alterar estes campos", "fields": forbiddenFields})
 			return
 		}
 
-		if result.MatchedCount == 0 {
+		var request model.UserAdminUpdate
+		if err := json.Unmarshal(body, &request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados de atualização inválidos", "detalhe": err.Error()})
+			return
+		}
+
+		validationErrors := validate.Struct(request)
+		if validationErrors != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErrors.Error()})
+			return
+		}
+
+		if request.Birthday != nil && (request.Birthday.After(time.Now()) || request.Birthday.Year() < 1900) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Data de nascimento inválida"})
+			return
+		}
+
+		if request.UserType != nil && !helper.RoleExists(*request.UserType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "tipo de usuário inválido"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		filter := bson.M{"uid": targetUserId}
+
+		var current model.User
+		if err := userCollection.FindOne(ctx, filter).Decode(&current); err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
 			return
 		}
 
+		updates, changed := buildUserUpdate(request, current)
+
+		if contains(changed, "userType") && userId == targetUserId {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Você não pode alterar seu próprio tipo de usuário"})
+			return
+		}
+
+		if userId != targetUserId && changesAdminFields(changed) && current.UserType != nil {
+			if err := helper.CheckRoleAssignment(c, *current.UserType); err != nil {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Você não tem permissão para alterar um usuário com este tipo"})
+				return
+			}
+		}
+
+		if departmentId, ok := updates["departmentId"].(string); ok && departmentId != "" {
+			if !departmentExists(ctx, departmentId) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Departamento não encontrado"})
+				return
+			}
+		}
+
+		if managerId, ok := updates["managerId"].(string); ok && managerId != "" {
+			cycle, err := createsManagerCycle(ctx, targetUserId, managerId)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if cycle {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "O gestor informado criaria um ciclo na hierarquia"})
+				return
+			}
+		}
+
+		if email, ok := updates["email"]; ok {
+			count, err := userCollection.CountDocuments(ctx, bson.M{"email": email, "uid": bson.M{"$ne": targetUserId}})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao verificar email"})
+				return
+			}
+			if count > 0 {
+				c.JSON(http.StatusConflict, gin.H{"error": "email já está em uso"})
+				return
+			}
+		}
+
+		if len(changed) > 0 {
+			_, err = userCollection.UpdateOne(ctx, filter, bson.M{"$set": updates})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar os dados do usuário"})
+				return
+			}
+		}
+
 		var userProfile model.User
 		err = userCollection.FindOne(ctx, filter).Decode(&userProfile)
 		if err != nil {
@@ -301,24 +688,136 @@ func UpdateOneUser() gin.HandlerFunc {
 			return
 		}
 
+		if len(changed) > 0 && (userId != targetUserId || changesAdminFields(changed)) {
+			helper.Audit(c, helper.AuditUserUpdate, "user", targetUserId, current, userProfile)
+		}
+
+		helper.ApplyPrivacy(&userProfile, helper.ViewerFromContext(c))
+
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Usuário atualizado com sucesso",
-			"result":  result,
+			"changed": changed,
 			"user":    userProfile,
 		})
 	}
 }
 
+var directorySortFields = map[string]string{
+	"name":      "name",
+	"entryDate": "entryDate",
+}
+
+var errEmailSearchForbidden = errors.New("você não tem permissão para buscar por email")
+
+func directoryFilter(c *gin.Context) (bson.M, error) {
+	filter := bson.M{}
+
+	email := strings.TrimSpace(c.Query("email"))
+
+	if query := strings.TrimSpace(c.Query("q")); strings.Contains(query, "@") {
+		email = query
+	} else if query != "" {
+		filter["$text"] = bson.M{"$search": query}
+	}
+
+	if email != "" {
+		if helper.CheckPermission(c, helper.PermUserRead) != nil {
+			return nil, errEmailSearchForbidden
+		}
+		filter["email"] = bson.M{"$regex": primitive.Regex{Pattern: "^" + helper.EscapeRegex(email) + "$", Options: "i"}}
+	}
+
+	if name := strings.TrimSpace(c.Query("name")); name != "" {
+		filter["name"] = bson.M{"$regex": primitive.Regex{Pattern: helper.EscapeRegex(name), Options: "i"}}
+	}
+
+	if role := strings.TrimSpace(c.Query("role")); role != "" {
+		filter["role"] = bson.M{"$regex": primitive.Regex{Pattern: "^" + helper.EscapeRegex(role) + "$", Options: "i"}}
+	}
+
+	if departmentId := c.Query("departmentId"); departmentId != "" {
+		filter["departmentId"] = departmentId
+	}
+
+	if userType := c.Query("userType"); userType != "" {
+		filter["userType"] = strings.ToUpper(userType)
+	}
+
+	switch c.DefaultQuery("active", "true") {
+	case "true":
+		filter["active"] = bson.M{"$ne": false}
+	case "false":
+		filter["active"] = false
+	case "all":
+	default:
+		return nil, errors.New("filtro active inválido")
+	}
+
+	return filter, nil
+}
+
 func SearchUsers() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		name := c.DefaultQuery("name", "")
+		if c.DefaultQuery("active", "true") != "true" {
+			if err := helper.CheckPermission(c, helper.PermUserDeactivate); err != nil {
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		filter, err := directoryFilter(c)
+		if err == errEmailSearchForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		sortParam := c.DefaultQuery("sort", "name")
+		descending := strings.HasPrefix(sortParam, "-")
+		sortField, ok := directorySortFields[strings.TrimPrefix(sortParam, "-")]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "campo de ordenação inválido"})
+			return
+		}
+
+		direction := 1
+		if descending {
+			direction = -1
+		}
+
+		if cursorParam := c.Query("cursor"); cursorParam != "" {
+			cursor, err := helper.DecodeCursor(cursorParam)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			var value interface{} = cursor.Value
+			if sortField == "entryDate" {
+				if value, err = cursor.Time(); err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+					return
+				}
+			}
+
+			for key, condition := range helper.KeysetFilter(sortField, value, cursor.Id, descending) {
+				filter[key] = condition
+			}
+		}
+
+		limit := helper.ParsePageLimit(c.Query("limit"))
 
 		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
 		defer cancel()
 
-		var users []model.User
+		opts := options.Find().
+			SetSort(bson.D{{Key: sortField, Value: direction}, {Key: "_id", Value: direction}}).
+			SetLimit(int64(limit + 1))
 
-		cursor, err := userCollection.Find(ctx, bson.M{"name": bson.M{"$regex": primitive.Regex{Pattern: ".*" + name + ".*", Options: "i"}}})
+		cursor, err := userCollection.Find(ctx, filter, opts)
 		if err != nil {
 			log.Println("Erro ao buscar usuários:", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar usuários"})
@@ -326,6 +825,9 @@ func SearchUsers() gin.HandlerFunc {
 		}
 		defer cursor.Close(ctx)
 
+		viewer := helper.ViewerFromContext(c)
+
+		users := []model.User{}
 		for cursor.Next(ctx) {
 			var user model.User
 			if err := cursor.Decode(&user); err != nil {
@@ -333,8 +835,7 @@ func SearchUsers() gin.HandlerFunc {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar usuários"})
 				return
 			}
-			user.Password = nil
-			user.Email = nil
+			helper.ApplyPrivacy(&user, viewer)
 			users = append(users, user)
 		}
 
@@ -343,7 +844,19 @@ func SearchUsers() gin.HandlerFunc {
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"users": users})
+		hasMore := len(users) > limit
+		nextCursor := ""
+		if hasMore {
+			users = users[:limit]
+			last := users[limit-1]
+			if sortField == "entryDate" {
+				nextCursor = helper.EncodeTimeCursor(last.EntryDate, last.ID)
+			} else if last.Name != nil {
+				nextCursor = helper.EncodeCursor(*last.Name, last.ID)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"users": users, "nextCursor": nextCursor, "hasMore": hasMore})
 	}
 }
 
@@ -362,61 +875,448 @@ func GetOneUser() gin.HandlerFunc {
 		err := userCollection.FindOne(ctx, bson.M{"uid": userId}).Decode(&user)
 		defer cancel()
 
-		user.Password = nil
-		user.Email = nil
-
 		if err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "usuário não encontrado", "erro": err.Error()})
 			return
 		}
 
+		helper.ApplyPrivacy(&user, helper.ViewerFromContext(c))
+
 		c.JSON(http.StatusOK, user)
 	}
 
 }
 
+const maxBirthdayDays = 366
+
+func birthdayRange(c *gin.Context) (time.Time, time.Time, error) {
+	today := helper.CompanyNow()
+
+	if monthParam := c.Query("month"); monthParam != "" {
+		month, err := strconv.Atoi(monthParam)
+		if err != nil || month < 1 || month > 12 {
+			return time.Time{}, time.Time{}, errors.New("mês inválido")
+		}
+
+		year, err := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(today.Year())))
+		if err != nil || year < 1900 || year > 9999 {
+			return time.Time{}, time.Time{}, errors.New("ano inválido")
+		}
+
+		start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0), nil
+	}
+
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if fromParam := c.Query("from"); fromParam != "" {
+		from, err := time.Parse("2006-01-02", fromParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("data inicial inválida, use AAAA-MM-DD")
+		}
+		start = from
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 || days > maxBirthdayDays {
+		return time.Time{}, time.Time{}, errors.New("quantidade de dias inválida")
+	}
+
+	return start, start.AddDate(0, 0, days), nil
+}
+
 func GetBirthdays() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		start, end, err := birthdayRange(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
 		defer cancel()
 
-		currentDate := time.Now()
-		currentMonthDay := fmt.Sprintf("%02d-%02d", currentDate.Month(), currentDate.Day())
-
-		pipeline := mongo.Pipeline{
-			{{Key: "$addFields", Value: bson.M{
-				"birthdaySortable": bson.M{
-					"$substr": []interface{}{"$birthday", 5, 5},
-				},
-			}}},
-			{{Key: "$match", Value: bson.M{
-				"birthdaySortable": bson.M{"$gte": currentMonthDay},
-			}}},
-			{{Key: "$sort", Value: bson.M{"birthdaySortable": 1}}},
-			{{Key: "$project", Value: bson.M{
-				"name":              1,
-				"profilePictureUrl": 1,
-				"role":              1,
-				"birthday":          1,
-				"_id":               0,
-			}}},
-		}
-
-		cursor, err := userCollection.Aggregate(ctx, pipeline)
+		birthdays, err := helper.BirthdayCalendar(ctx, start, end, helper.ViewerFromContext(c))
 		if err != nil {
-			log.Printf("Erro ao executar agregação: %v\n", err)
+			log.Printf("Erro ao buscar aniversários: %v\n", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar aniversários"})
 			return
 		}
-		defer cursor.Close(ctx)
 
-		var users []bson.M
-		if err := cursor.All(ctx, &users); err != nil {
-			log.Printf("Erro ao decodificar usuários: %v\n", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar dados dos usuários"})
+		c.JSON(http.StatusOK, gin.H{
+			"birthdays": birthdays,
+			"from":      start.Format("2006-01-02"),
+			"to":        end.AddDate(0, 0, -1).Format("2006-01-02"),
+			"timezone":  helper.CompanyLocation.String(),
+		})
+	}
+}
+
+func createPasswordReset(ctx context.Context, user model.User, ip string, duration time.Duration) (string, error) {
+	now := time.Now()
+
+	_, err := passwordResetCollection.UpdateMany(
+		ctx,
+		bson.M{"userId": user.Uid, "usedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"usedAt": now}},
+	)
+	if err != nil {
+		log.Printf("Erro ao invalidar tokens anteriores: %v\n", err)
+	}
+
+	token := helper.NewTokenId()
+
+	reset := model.PasswordReset{
+		ID:        primitive.NewObjectID(),
+		UserId:    user.Uid,
+		TokenHash: helper.HashToken(token),
+		IP:        ip,
+		CreatedAt: now,
+		ExpiresAt: now.Add(duration),
+	}
+
+	_, err = passwordResetCollection.InsertOne(ctx, reset)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func passwordResetLink(token string) string {
+	resetUrl := os.Getenv("PASSWORD_RESET_URL")
+	if resetUrl == "" {
+		resetUrl = "http://192.168.1.68:9000/auth/reset-password"
+	}
+
+	return resetUrl + "?token=" + token
+}
+
+func ForgotPassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			Email string `json:"email" validate:"required,email"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "erro ao ler dados"})
+			return
+		}
+
+		if err := validate.Struct(request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "email inválido"})
+			return
+		}
+
+		response := gin.H{"message": "Se o email estiver cadastrado, um link de redefinição será enviado"}
+
+		if retryAfter := helper.PasswordResetRateLimit.Allow(request.Email, c.ClientIP()); retryAfter > 0 {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "muitas solicitações, tente novamente mais tarde", "retryAfter": seconds})
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"birthdays": users})
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		var user model.User
+		err := userCollection.FindOne(ctx, bson.M{"email": request.Email}).Decode(&user)
+		if err != nil || !user.IsActive() {
+			c.JSON(http.StatusOK, response)
+			return
+		}
+
+		if user.Pending {
+			revokeInvitations(ctx, user.Uid)
+			token, _, err := createInvitation(ctx, user, "", c.ClientIP())
+			if err == nil {
+				err = sendInvitation(user, token)
+			}
+			if err != nil {
+				log.Printf("Erro ao reenviar convite para %s: %v\n", *user.Email, err)
+			}
+			c.JSON(http.StatusOK, response)
+			return
+		}
+
+		token, err := createPasswordReset(ctx, user, c.ClientIP(), passwordResetDuration)
+		if err != nil {
+			log.Printf("Erro ao gerar redefinição de senha para %s: %v\n", *user.Email, err)
+			c.JSON(http.StatusOK, response)
+			return
+		}
+
+		err = helper.SendMail(
+			*user.Email,
+			"Redefinição de senha",
+			fmt.Sprintf(
+				"Olá, %s!\n\nPara redefinir sua senha acesse o link abaixo. Ele expira em %d minutos.\n\n%s\n\nSe você não solicitou a redefinição, ignore este email.",
+				*user.Name,
+				int(passwordResetDuration.Minutes()),
+				passwordResetLink(token),
+			),
+		)
+		if err != nil {
+			log.Printf("Erro ao enviar email de redefinição para %s: %v\n", *user.Email, err)
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func ResetPassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			Token    string `json:"token" validate:"required"`
+			Password string `json:"password" validate:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "erro ao ler dados"})
+			return
+		}
+
+		if err := validate.Struct(request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "token e senha são obrigatórios"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		filter := bson.M{
+			"tokenHash": helper.HashToken(request.Token),
+			"usedAt":    bson.M{"$exists": false},
+			"expiresAt": bson.M{"$gt": time.Now()},
+		}
+
+		var reset model.PasswordReset
+		if err := passwordResetCollection.FindOne(ctx, filter).Decode(&reset); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "token inválido ou expirado"})
+			return
+		}
+
+		var user model.User
+		if err := userCollection.FindOne(ctx, bson.M{"uid": reset.UserId}).Decode(&user); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+
+		if !user.IsActive() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Usuário desativado"})
+			return
+		}
+
+		if err := helper.DefaultPasswordPolicy.Validate(request.Password, user); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		result, err := passwordResetCollection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"usedAt": time.Now()}})
+		if err != nil || result.ModifiedCount == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "token inválido ou expirado"})
+			return
+		}
+
+		if err := setUserPassword(ctx, user, request.Password); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar senha"})
+			return
+		}
+
+		helper.RevokeUserSessions(reset.UserId, "", "password-reset")
+
+		c.JSON(http.StatusOK, gin.H{"message": "Senha redefinida com sucesso"})
+	}
+}
+
+func ChangePassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaims, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			return
+		}
+
+		claims, ok := userClaims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
+			return
+		}
+
+		var request struct {
+			CurrentPassword string `json:"currentPassword" validate:"required"`
+			NewPassword     string `json:"newPassword" validate:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "erro ao ler dados"})
+			return
+		}
+
+		if err := validate.Struct(request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "senha atual e nova senha são obrigatórias"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		var user model.User
+		if err := userCollection.FindOne(ctx, bson.M{"uid": claims["Uid"].(string)}).Decode(&user); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+
+		if user.Password == nil || VerifyPassword(request.CurrentPassword, *user.Password) != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "senha atual incorreta"})
+			return
+		}
+
+		if err := helper.DefaultPasswordPolicy.Validate(request.NewPassword, user); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := setUserPassword(ctx, user, request.NewPassword); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar senha"})
+			return
+		}
+
+		sessionId, _ := claims["SessionId"].(string)
+		revoked, _ := helper.RevokeUserSessions(user.Uid, sessionId, "password-change")
+
+		c.JSON(http.StatusOK, gin.H{"message": "Senha alterada com sucesso", "revokedSessions": revoked})
+	}
+}
+
+func UnlockUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId := c.Param("userId")
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		var user model.User
+		if err := userCollection.FindOne(ctx, bson.M{"uid": userId}).Decode(&user); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado"})
+			return
+		}
+
+		cleared, err := helper.UnlockLogin(*user.Email, c.Query("ip"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao desbloquear usuário"})
+			return
+		}
+
+		helper.Audit(c, helper.AuditUserUnlock, "user", user.Uid, nil, bson.M{"ip": c.Query("ip"), "cleared": cleared})
+
+		c.JSON(http.StatusOK, gin.H{"message": "Usuário desbloqueado com sucesso", "cleared": cleared})
+	}
+}
+
+func revokeUserAccess(ctx context.Context, userId string) (int64, int64) {
+	helper.InvalidateUserStatus(userId)
+	revoked, _ := helper.RevokeUserSessions(userId, "", "deactivated")
+
+	devices, err := tokenCollection.DeleteMany(ctx, bson.M{"userId": userId})
+	if err != nil {
+		log.Printf("Erro ao remover dispositivos do usuário %s: %v\n", userId, err)
+	}
+
+	var removedDevices int64
+	if devices != nil {
+		removedDevices = devices.DeletedCount
+	}
+
+	return revoked, removedDevices
+}
+
+func DeactivateUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaims, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			return
+		}
+
+		claims, ok := userClaims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar token"})
+			return
+		}
+
+		userId := c.Param("userId")
+
+		if userId == claims["Uid"].(string) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Você não pode desativar sua própria conta"})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		result, err := userCollection.UpdateOne(
+			ctx,
+			bson.M{"uid": userId, "active": bson.M{"$ne": false}},
+			bson.M{"$set": bson.M{
+				"active":        false,
+				"deactivatedAt": time.Now(),
+				"deactivatedBy": claims["Uid"].(string),
+			}},
+		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao desativar usuário"})
+			return
+		}
+
+		if result.MatchedCount == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado ou já desativado"})
+			return
+		}
+
+		revoked, removedDevices := revokeUserAccess(ctx, userId)
+
+		helper.Audit(c, helper.AuditUserDeactivate, "user", userId,
+			bson.M{"active": true},
+			bson.M{"active": false, "revokedSessions": revoked, "removedDevices": removedDevices},
+		)
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":         "Usuário desativado com sucesso",
+			"revokedSessions": revoked,
+			"removedDevices":  removedDevices,
+		})
+	}
+}
+
+func ReactivateUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId := c.Param("userId")
+
+		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		defer cancel()
+
+		result, err := userCollection.UpdateOne(
+			ctx,
+			bson.M{"uid": userId, "active": false},
+			bson.M{
+				"$set":   bson.M{"active": true},
+				"$unset": bson.M{"deactivatedAt": "", "deactivatedBy": ""},
+			},
+		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao reativar usuário"})
+			return
+		}
+
+		if result.MatchedCount == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Usuário não encontrado ou já ativo"})
+			return
+		}
+
+		helper.InvalidateUserStatus(userId)
+		helper.Audit(c, helper.AuditUserReactivate, "user", userId, bson.M{"active": false}, bson.M{"active": true})
+
+		c.JSON(http.StatusOK, gin.H{"message": "Usuário reativado com sucesso"})
 	}
 }
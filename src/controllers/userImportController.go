@@ -0,0 +1,393 @@
+package controllers
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	helper "github.com/Nooksd/go-server/src/helpers"
+	model "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const importMaxSize = 5 << 20
+
+var importColumns = map[string]string{
+	"name":        "name",
+	"nome":        "name",
+	"email":       "email",
+	"role":        "role",
+	"cargo":       "role",
+	"phone":       "phone",
+	"telefone":    "phone",
+	"birthday":    "birthday",
+	"aniversario": "birthday",
+	"entrydate":   "entryDate",
+	"entry date":  "entryDate",
+	"admissao":    "entryDate",
+	"usertype":    "userType",
+	"user type":   "userType",
+	"tipo":        "userType",
+}
+
+const importDefaultUserType = "USER"
+
+var importBaseTimeout = 30 * time.Second
+var importRowTimeout = time.Second
+
+var importDateLayouts = []string{"2006-01-02", "02/01/2006", time.RFC3339}
+
+type importRowResult struct {
+	Row    int      `json:"row"`
+	Email  string   `json:"email"`
+	Action string   `json:"action"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+func parseImportDate(value string) (time.Time, error) {
+	for _, layout := range importDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return helper.CalendarDate(date), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("data inválida: %s", value)
+}
+
+func readImportFile(c *gin.Context) (io.Reader, error) {
+	if file, _, err := c.Request.FormFile("file"); err == nil {
+		return io.LimitReader(file, importMaxSize), nil
+	}
+
+	if strings.HasPrefix(c.ContentType(), "text/csv") || strings.HasPrefix(c.ContentType(), "text/plain") {
+		return io.LimitReader(c.Request.Body, importMaxSize), nil
+	}
+
+	return nil, fmt.Errorf("nenhum arquivo CSV enviado")
+}
+
+func parseImportCSV(reader io.Reader) ([]map[string]string, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimPrefix(string(content), "\ufeff")
+	firstLine := strings.SplitN(text, "\n", 2)[0]
+
+	csvReader := csv.NewReader(strings.NewReader(text))
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		csvReader.Comma = ';'
+	}
+	csvReader.TrimLeadingSpace = true
+	csvReader.FieldsPerRecord = -1
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSV inválido: %v", err)
+	}
+
+	if len(records) < 2 {
+		return nil, fmt.Errorf("CSV sem linhas de dados")
+	}
+
+	header := make([]string, len(records[0]))
+	for i, column := range records[0] {
+		key, ok := importColumns[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			return nil, fmt.Errorf("coluna desconhecida: %s", column)
+		}
+		header[i] = key
+	}
+
+	var rows []map[string]string
+	for _, record := range records[1:] {
+		row := map[string]string{}
+		for i, value := range record {
+			if i < len(header) {
+				row[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func buildImportUser(row map[string]string) (model.User, []string) {
+	var errors []string
+	var user model.User
+
+	name := row["name"]
+	email := strings.ToLower(row["email"])
+
+	user.Name = &name
+	user.Email = &email
+
+	if userType := strings.ToUpper(row["userType"]); userType != "" {
+		user.UserType = &userType
+		if !helper.RoleExists(userType) {
+			errors = append(errors, "tipo de usuário inválido: "+userType)
+		}
+	}
+
+	if role := row["role"]; role != "" {
+		user.Role = &role
+	}
+	if phone := row["phone"]; phone != "" {
+		user.PhoneNumber = &phone
+	}
+
+	if value := row["birthday"]; value != "" {
+		date, err := parseImportDate(value)
+		if err != nil {
+			errors = append(errors, err.Error())
+		}
+		user.Birthday = date
+	}
+
+	if value := row["entryDate"]; value != "" {
+		date, err := parseImportDate(value)
+		if err != nil {
+			errors = append(errors, err.Error())
+		}
+		user.EntryDate = date
+	}
+
+	if err := validate.StructExcept(user, "Password", "UserType"); err != nil {
+		errors = append(errors, err.Error())
+	} else if err := validate.Var(email, "email"); err != nil {
+		errors = append(errors, "email inválido")
+	}
+
+	return user, errors
+}
+
+func importUpdate(user model.User) bson.M {
+	update := bson.M{"name": *user.Name}
+
+	if user.UserType != nil {
+		update["userType"] = *user.UserType
+	}
+
+	if user.Role != nil {
+		update["role"] = *user.Role
+	}
+	if user.PhoneNumber != nil {
+		update["phoneNumber"] = *user.PhoneNumber
+	}
+	if !user.Birthday.IsZero() {
+		update["birthday"] = user.Birthday
+	}
+	if !user.EntryDate.IsZero() {
+		update["entryDate"] = user.EntryDate
+	}
+
+	return update
+}
+
+func importRoleErrors(c *gin.Context, user model.User, existing model.User) []string {
+	if user.UserType == nil || (existing.UserType != nil && *existing.UserType == *user.UserType) {
+		return nil
+	}
+
+	var errors []string
+	if err := helper.CheckRoleAssignment(c, *user.UserType); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if existing.UserType != nil {
+		if err := helper.CheckRoleAssignment(c, *existing.UserType); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+	return errors
+}
+
+func newColleaguesText(names []string) string {
+	if len(names) == 1 {
+		return fmt.Sprintf("Novo funcionário adicionado: %s", names[0])
+	}
+
+	shown := names
+	if len(shown) > 5 {
+		shown = shown[:5]
+	}
+
+	text := fmt.Sprintf("%d novos colegas chegaram: %s", len(names), strings.Join(shown, ", "))
+	if len(names) > len(shown) {
+		text += fmt.Sprintf(" e mais %d", len(names)-len(shown))
+	}
+	return text
+}
+
+func ImportUsers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		commit := c.DefaultQuery("mode", "dry-run") == "commit"
+
+		reader, err := readImportFile(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		rows, err := parseImportCSV(reader)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var ctx, cancel = context.WithTimeout(context.Background(), importBaseTimeout+time.Duration(len(rows))*importRowTimeout)
+		defer cancel()
+
+		results := []importRowResult{}
+		seen := map[string]int{}
+		var createdNames []string
+		var createdUsers []model.User
+		summary := map[string]int{"create": 0, "update": 0, "error": 0}
+
+		for i, row := range rows {
+			rowNumber := i + 2
+			user, rowErrors := buildImportUser(row)
+			result := importRowResult{Row: rowNumber, Email: *user.Email}
+
+			if previous, ok := seen[*user.Email]; ok && *user.Email != "" {
+				rowErrors = append(rowErrors, fmt.Sprintf("email duplicado na linha %d", previous))
+			}
+			seen[*user.Email] = rowNumber
+
+			if len(rowErrors) > 0 {
+				result.Action = "error"
+				result.Errors = rowErrors
+				results = append(results, result)
+				summary["error"]++
+				continue
+			}
+
+			var existing model.User
+			emailFilter := bson.M{"email": bson.M{"$in": []string{row["email"], *user.Email}}}
+			err := userCollection.FindOne(ctx, emailFilter).Decode(&existing)
+			if err == mongo.ErrNoDocuments && user.UserType == nil {
+				defaultUserType := importDefaultUserType
+				user.UserType = &defaultUserType
+			}
+
+			if roleErrors := importRoleErrors(c, user, existing); len(roleErrors) > 0 {
+				result.Action = "error"
+				result.Errors = roleErrors
+				results = append(results, result)
+				summary["error"]++
+				continue
+			}
+
+			switch {
+			case err == nil:
+				result.Action = "update"
+				if commit {
+					var updated model.User
+					err = userCollection.FindOneAndUpdate(
+						ctx,
+						bson.M{"uid": existing.Uid},
+						bson.M{"$set": importUpdate(user)},
+						options.FindOneAndUpdate().SetReturnDocument(options.After),
+					).Decode(&updated)
+					if err == nil {
+						helper.Audit(c, helper.AuditUserImport, "user", existing.Uid, existing, updated)
+					}
+				}
+			case err == mongo.ErrNoDocuments:
+				result.Action = "create"
+				err = nil
+				if commit {
+					var created model.User
+					created, err = insertImportedUser(ctx, user)
+					if err == nil {
+						helper.Audit(c, helper.AuditUserImport, "user", created.Uid, nil, created)
+						createdNames = append(createdNames, *user.Name)
+						createdUsers = append(createdUsers, created)
+					}
+				}
+			}
+
+			if err != nil {
+				log.Printf("Erro ao importar linha %d: %v\n", rowNumber, err)
+				result.Action = "error"
+				result.Errors = []string{"erro ao salvar usuário"}
+			}
+
+			summary[result.Action]++
+			results = append(results, result)
+		}
+
+		sendImportInvitations(helper.GetClaim(c, "Uid"), c.ClientIP(), createdUsers)
+
+		if len(createdNames) > 0 {
+			helper.CreateNotification(newColleaguesText(createdNames), "contact")
+		}
+
+		mode := "dry-run"
+		if commit {
+			mode = "commit"
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"mode":    mode,
+			"summary": summary,
+			"rows":    results,
+		})
+	}
+}
+
+func insertImportedUser(ctx context.Context, user model.User) (model.User, error) {
+	imported := user
+	applyUserDefaults(&imported)
+
+	imported.Password = nil
+	imported.Pending = true
+
+	if user.Role != nil {
+		imported.Role = user.Role
+	}
+	if user.PhoneNumber != nil {
+		imported.PhoneNumber = user.PhoneNumber
+	}
+	if !user.Birthday.IsZero() {
+		imported.Birthday = user.Birthday
+	}
+	if !user.EntryDate.IsZero() {
+		imported.EntryDate = user.EntryDate
+	}
+
+	if _, err := userCollection.InsertOne(ctx, imported); err != nil {
+		return model.User{}, err
+	}
+
+	return imported, nil
+}
+
+func sendImportInvitations(invitedBy string, ip string, users []model.User) {
+	if len(users) == 0 {
+		return
+	}
+
+	go func() {
+		for _, user := range users {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+
+			token, _, err := createInvitation(ctx, user, invitedBy, ip)
+			if err != nil {
+				log.Printf("Erro ao gerar convite para %s: %v\n", *user.Email, err)
+			} else if err := sendInvitation(user, token); err != nil {
+				log.Printf("Erro ao enviar convite para %s: %v\n", *user.Email, err)
+			}
+
+			cancel()
+		}
+	}()
+}
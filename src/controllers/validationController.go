@@ -79,11 +79,6 @@ func AcceptValidation() gin.HandlerFunc {
 			return
 		}
 
-		if claims["UserType"].(string) != "ADMIN" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Permissão negada"})
-			return
-		}
-
 		validationId := c.Param("validationId")
 
 		validationID, err := primitive.ObjectIDFromHex(validationId)
@@ -165,6 +160,15 @@ func AcceptValidation() gin.HandlerFunc {
 			return
 		}
 
+		helper.Audit(
+			c,
+			helper.AuditValidationAccept,
+			"validation",
+			validationId,
+			bson.M{"status": "pending"},
+			bson.M{"status": "validated", "userId": validation.UserID, "missionId": missionId, "points": mission.Value},
+		)
+
 		helper.CreateNotification(
 			fmt.Sprintf(
 				"Missão completa com sucesso! %d pontos adicionados",
@@ -190,11 +194,6 @@ func RejectValidation() gin.HandlerFunc {
 			return
 		}
 
-		if claims["UserType"].(string) != "ADMIN" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Permissão negada"})
-			return
-		}
-
 		validationID, err := primitive.ObjectIDFromHex(c.Param("validationId"))
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "ID de validação inválido"})
@@ -220,6 +219,8 @@ func RejectValidation() gin.HandlerFunc {
 			return
 		}
 
+		helper.Audit(c, helper.AuditValidationReject, "validation", validationID.Hex(), bson.M{"status": "pending"}, bson.M{"status": "rejected"})
+
 		c.JSON(http.StatusOK, gin.H{"message": "Validação rejeitada com sucesso"})
 	}
 }
@@ -0,0 +1,184 @@
+package helpers
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	models "github.com/Nooksd/go-server/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const anniversarySettingsId = "anniversaries"
+const anniversaryJob = "anniversary"
+
+var settingsCollection = database.OpenCollection(database.Client, "settings")
+var postCollection = database.OpenCollection(database.Client, "posts")
+
+var DefaultAnniversarySettings = models.AnniversarySettings{
+	ID:         anniversarySettingsId,
+	Enabled:    true,
+	Notify:     true,
+	CreatePost: true,
+	Hour:       9,
+	Message:    "Hoje {name} completa {years} de empresa! Parabéns! 🎉",
+	AuthorName: "Connect",
+}
+
+var anniversaryJobInterval = time.Minute * 15
+
+func GetAnniversarySettings(ctx context.Context) models.AnniversarySettings {
+	settings := DefaultAnniversarySettings
+
+	err := settingsCollection.FindOne(ctx, bson.M{"_id": anniversarySettingsId}).Decode(&settings)
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Printf("Erro ao buscar configurações de aniversário de empresa: %v\n", err)
+	}
+
+	return settings
+}
+
+func SaveAnniversarySettings(ctx context.Context, settings models.AnniversarySettings) error {
+	settings.ID = anniversarySettingsId
+	settings.UpdatedAt = time.Now()
+
+	_, err := settingsCollection.ReplaceOne(ctx, bson.M{"_id": anniversarySettingsId}, settings, options.Replace().SetUpsert(true))
+	return err
+}
+
+func UpcomingAnniversaries(ctx context.Context, from time.Time, days int) ([]models.Anniversary, error) {
+	filter := bson.M{"active": bson.M{"$ne": false}, "pending": bson.M{"$ne": true}, "entryDate": bson.M{"$exists": true}}
+	projection := bson.M{"uid": 1, "name": 1, "role": 1, "profilePictureUrl": 1, "entryDate": 1}
+
+	cursor, err := userCollection.Find(ctx, filter, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	start := CalendarDate(from)
+	end := start.AddDate(0, 0, days)
+
+	anniversaries := []models.Anniversary{}
+	for _, user := range users {
+		if user.EntryDate.IsZero() {
+			continue
+		}
+
+		entry := user.EntryDate.UTC()
+		for _, date := range OccurrencesBetween(entry, start, end) {
+			years := date.Year() - entry.Year()
+			if years < 1 {
+				continue
+			}
+
+			anniversary := models.Anniversary{
+				Uid:               user.Uid,
+				ProfilePictureUrl: user.ProfilePictureUrl,
+				EntryDate:         user.EntryDate,
+				Date:              date,
+				Years:             years,
+			}
+			if user.Name != nil {
+				anniversary.Name = *user.Name
+			}
+			if user.Role != nil {
+				anniversary.Role = *user.Role
+			}
+			anniversaries = append(anniversaries, anniversary)
+		}
+	}
+
+	sort.Slice(anniversaries, func(i, j int) bool {
+		if anniversaries[i].Date.Equal(anniversaries[j].Date) {
+			return anniversaries[i].Name < anniversaries[j].Name
+		}
+		return anniversaries[i].Date.Before(anniversaries[j].Date)
+	})
+
+	return anniversaries, nil
+}
+
+func AnniversaryMessage(template string, anniversary models.Anniversary) string {
+	years := strconv.Itoa(anniversary.Years) + " anos"
+	if anniversary.Years == 1 {
+		years = "1 ano"
+	}
+
+	return strings.NewReplacer("{name}", anniversary.Name, "{years}", years).Replace(template)
+}
+
+func celebrateAnniversary(ctx context.Context, settings models.AnniversarySettings, anniversary models.Anniversary) error {
+	text := AnniversaryMessage(settings.Message, anniversary)
+
+	if settings.CreatePost {
+		post := models.Post{
+			ID:        primitive.NewObjectID(),
+			OwnerId:   "system",
+			Name:      settings.AuthorName,
+			AvatarURL: anniversary.ProfilePictureUrl,
+			Role:      "Celebração",
+			Text:      text,
+			Hashtags:  []string{"aniversariodeempresa"},
+			Likes:     []string{},
+			Comments:  []models.Comment{},
+			CreatedAt: time.Now(),
+		}
+
+		if _, err := postCollection.InsertOne(ctx, post); err != nil {
+			return err
+		}
+	}
+
+	if settings.Notify {
+		CreateNotification(text, "feed")
+	}
+
+	return nil
+}
+
+func RunAnniversaryJob(now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	now = now.In(CompanyLocation)
+
+	settings := GetAnniversarySettings(ctx)
+	if !settings.Enabled || now.Hour() < settings.Hour {
+		return
+	}
+
+	anniversaries, err := UpcomingAnniversaries(ctx, now, 1)
+	if err != nil {
+		log.Printf("Erro ao buscar aniversários de empresa: %v\n", err)
+		return
+	}
+
+	for _, anniversary := range anniversaries {
+		key := anniversary.Uid + ":" + strconv.Itoa(anniversary.Date.Year())
+		if !ClaimJobRun(ctx, anniversaryJob, key) {
+			continue
+		}
+
+		if err := celebrateAnniversary(ctx, settings, anniversary); err != nil {
+			log.Printf("Erro ao celebrar aniversário de empresa de %s: %v\n", anniversary.Uid, err)
+			ReleaseJobRun(ctx, anniversaryJob, key)
+		}
+	}
+}
+
+func StartAnniversaryJob() {
+	RunPeriodically(anniversaryJob, anniversaryJobInterval, RunAnniversaryJob)
+}
@@ -0,0 +1,118 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	models "github.com/Nooksd/go-server/src/models"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const ApiKeyPrefix = "sk_"
+const ServiceUserType = "SERVICE"
+
+var ErrInvalidApiKey = errors.New("chave de API inválida ou revogada")
+
+var serviceAccountCollection = database.OpenCollection(database.Client, "serviceAccounts")
+var apiKeyCollection = database.OpenCollection(database.Client, "apiKeys")
+
+var apiKeyLastUsedInterval = time.Minute
+
+var serviceAccountAvatarUrl = envString("SERVICE_ACCOUNT_AVATAR_URL", "http://192.168.1.68:9000/avatar/get/avatar1")
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := apiKeyCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.M{"keyHash": 1}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.M{"serviceAccountId": 1}},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de chaves de API: %v\n", err)
+	}
+}
+
+func GenerateApiKey() (key string, prefix string, hash string) {
+	secret := NewTokenId()
+	key = ApiKeyPrefix + secret
+	prefix = key[:len(ApiKeyPrefix)+8]
+	return key, prefix, HashToken(key)
+}
+
+func IsApiKey(value string) bool {
+	return strings.HasPrefix(value, ApiKeyPrefix)
+}
+
+func AuthenticateApiKey(key string) (models.ServiceAccount, models.ApiKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var apiKey models.ApiKey
+	err := apiKeyCollection.FindOne(ctx, bson.M{"keyHash": HashToken(key), "revokedAt": bson.M{"$exists": false}}).Decode(&apiKey)
+	if err != nil {
+		return models.ServiceAccount{}, models.ApiKey{}, ErrInvalidApiKey
+	}
+
+	now := time.Now()
+	if apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Before(now) {
+		return models.ServiceAccount{}, models.ApiKey{}, ErrInvalidApiKey
+	}
+
+	var account models.ServiceAccount
+	err = serviceAccountCollection.FindOne(ctx, bson.M{"uid": apiKey.ServiceAccountId, "disabled": false}).Decode(&account)
+	if err != nil {
+		return models.ServiceAccount{}, models.ApiKey{}, ErrInvalidApiKey
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > apiKeyLastUsedInterval {
+		_, err = apiKeyCollection.UpdateOne(ctx, bson.M{"_id": apiKey.ID}, bson.M{"$set": bson.M{"lastUsedAt": now}})
+		if err != nil {
+			log.Printf("Erro ao atualizar uso da chave de API: %v\n", err)
+		}
+	}
+
+	return account, apiKey, nil
+}
+
+func ServiceAccountClaims(account models.ServiceAccount, apiKey models.ApiKey) jwt.MapClaims {
+	scopes := make([]interface{}, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		scopes = append(scopes, scope)
+	}
+
+	return jwt.MapClaims{
+		"Email":             "",
+		"Name":              account.Name,
+		"ProfilePictureUrl": serviceAccountAvatarUrl,
+		"Role":              "Serviço",
+		"Uid":               account.Uid,
+		"UserType":          ServiceUserType,
+		"SessionId":         "",
+		"ServiceAccount":    true,
+		"ApiKeyId":          apiKey.ID.Hex(),
+		"Scopes":            scopes,
+	}
+}
+
+func HasScope(claims jwt.MapClaims, permission string) bool {
+	scopes, _ := claims["Scopes"].([]interface{})
+	for _, scope := range scopes {
+		if granted, ok := scope.(string); ok && permissionMatches(granted, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+func IsServiceAccount(claims jwt.MapClaims) bool {
+	isService, _ := claims["ServiceAccount"].(bool)
+	return isService
+}
@@ -0,0 +1,166 @@
+package helpers
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	models "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	AuditUserCreate            = "user.create"
+	AuditUserUpdate            = "user.update"
+	AuditUserInvite            = "user.invite"
+	AuditUserImport            = "user.import"
+	AuditUserDeactivate        = "user.deactivate"
+	AuditUserReactivate        = "user.reactivate"
+	AuditUserUnlock            = "user.unlock"
+	AuditRoleCreate            = "role.create"
+	AuditRoleUpdate            = "role.update"
+	AuditRoleDelete            = "role.delete"
+	AuditServiceAccountCreate  = "serviceaccount.create"
+	AuditServiceAccountDisable = "serviceaccount.disable"
+	AuditApiKeyCreate          = "apikey.create"
+	AuditApiKeyRevoke          = "apikey.revoke"
+	AuditMissionCreate         = "mission.create"
+	AuditMissionComplete       = "mission.complete"
+	AuditValidationAccept      = "validation.accept"
+	AuditValidationReject      = "validation.reject"
+	AuditNotificationBroadcast = "notification.broadcast"
+	AuditLDAPSync              = "ldap.sync"
+	AuditPostPin               = "post.pin"
+	AuditPostUnpin             = "post.unpin"
+	AuditPostRestore           = "post.restore"
+	AuditImpersonationStart    = "impersonation.start"
+	AuditImpersonatedRequest   = "impersonation.request"
+)
+
+var auditCollection = database.OpenCollection(database.Client, "auditLog")
+
+var auditRedactedFields = map[string]bool{
+	"password":         true,
+	"passwordHistory":  true,
+	"mfaSecret":        true,
+	"mfaPendingSecret": true,
+	"mfaLastStep":      true,
+	"mfaRecoveryCodes": true,
+	"keyHash":          true,
+}
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := auditCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "actorId", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "targetType", Value: 1}, {Key: "targetId", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "action", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "impersonatorId", Value: 1}, {Key: "createdAt", Value: -1}}, Options: options.Index().SetSparse(true)},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices do log de auditoria: %v\n", err)
+	}
+}
+
+func toDocument(value interface{}) bson.M {
+	document := bson.M{}
+	if value == nil {
+		return document
+	}
+
+	data, err := bson.Marshal(value)
+	if err != nil {
+		log.Printf("Erro ao serializar valor de auditoria: %v\n", err)
+		return document
+	}
+
+	if err := bson.Unmarshal(data, &document); err != nil {
+		log.Printf("Erro ao converter valor de auditoria: %v\n", err)
+	}
+	return document
+}
+
+func AuditDiff(before interface{}, after interface{}) map[string]models.AuditChange {
+	beforeDocument := toDocument(before)
+	afterDocument := toDocument(after)
+
+	changes := map[string]models.AuditChange{}
+
+	for key, afterValue := range afterDocument {
+		beforeValue, ok := beforeDocument[key]
+		if ok && reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+		changes[key] = models.AuditChange{Before: beforeValue, After: afterValue}
+	}
+
+	for key, beforeValue := range beforeDocument {
+		if _, ok := afterDocument[key]; !ok {
+			changes[key] = models.AuditChange{Before: beforeValue, After: nil}
+		}
+	}
+
+	for key := range changes {
+		if auditRedactedFields[key] {
+			changes[key] = models.AuditChange{Before: "[redacted]", After: "[redacted]"}
+		}
+	}
+
+	return changes
+}
+
+func newAuditEntry(c *gin.Context, action string, targetType string, targetId string) models.AuditEntry {
+	entry := models.AuditEntry{
+		ID:         primitive.NewObjectID(),
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetId,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		CreatedAt:  time.Now(),
+	}
+
+	if userClaims, exists := c.Get("user"); exists {
+		if claims, ok := userClaims.(jwt.MapClaims); ok {
+			entry.ActorId, _ = claims["Uid"].(string)
+			entry.ActorName, _ = claims["Name"].(string)
+			entry.ActorType, _ = claims["UserType"].(string)
+			entry.ImpersonatorId = Impersonator(claims)
+		}
+	}
+
+	return entry
+}
+
+func saveAuditEntry(entry models.AuditEntry) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := auditCollection.InsertOne(ctx, entry); err != nil {
+		log.Printf("Erro ao registrar auditoria %s em %s/%s: %v\n", entry.Action, entry.TargetType, entry.TargetId, err)
+	}
+}
+
+func Audit(c *gin.Context, action string, targetType string, targetId string, before interface{}, after interface{}) {
+	entry := newAuditEntry(c, action, targetType, targetId)
+	entry.Changes = AuditDiff(before, after)
+	saveAuditEntry(entry)
+}
+
+func AuditRequest(c *gin.Context, action string) {
+	entry := newAuditEntry(c, action, "user", GetClaim(c, "Uid"))
+	entry.Method = c.Request.Method
+	entry.Path = c.Request.URL.Path
+	entry.Status = c.Writer.Status()
+	saveAuditEntry(entry)
+}
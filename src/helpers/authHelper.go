@@ -4,27 +4,81 @@ import (
 	"errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 )
 
-func CheckUserType(c *gin.Context, role string) (err error) {
-	userType := c.GetString("user_type")
-	err = nil
-	if userType != role {
-		err = errors.New("acesso não autorizado")
-		return err
+func GetClaim(c *gin.Context, key string) string {
+	userClaims, exists := c.Get("user")
+	if !exists {
+		return ""
 	}
-	return err
+
+	claims, ok := userClaims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+
+	value, _ := claims[key].(string)
+	return value
 }
 
-func MatchUserTypeToUid(c *gin.Context, userId string) (err error) {
-	userType := c.GetString("user_type")
-	uid := c.GetString("uid")
-	err = nil
+func HasClaimsPermission(claims jwt.MapClaims, permission string) bool {
+	if IsServiceAccount(claims) {
+		return HasScope(claims, permission)
+	}
+
+	userType, _ := claims["UserType"].(string)
+	return HasPermission(userType, permission)
+}
 
-	if userType == "USER" && uid != userId {
+func CheckPermission(c *gin.Context, permission string) (err error) {
+	userClaims, exists := c.Get("user")
+	if !exists {
 		return errors.New("acesso não autorizado")
 	}
 
-	err = CheckUserType(c, userType)
-	return err
+	claims, ok := userClaims.(jwt.MapClaims)
+	if !ok || !HasClaimsPermission(claims, permission) {
+		return errors.New("acesso não autorizado")
+	}
+	return nil
+}
+
+func CanAssignRole(claims jwt.MapClaims, roleName string) bool {
+	role, ok := GetRole(roleName)
+	if !ok {
+		return false
+	}
+
+	if HasClaimsPermission(claims, PermRoleManage) {
+		return true
+	}
+
+	for _, permission := range role.Permissions {
+		if !HasClaimsPermission(claims, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+func CheckRoleAssignment(c *gin.Context, roleName string) (err error) {
+	userClaims, exists := c.Get("user")
+	if !exists {
+		return errors.New("acesso não autorizado")
+	}
+
+	claims, ok := userClaims.(jwt.MapClaims)
+	if !ok || !CanAssignRole(claims, roleName) {
+		return errors.New("você não tem permissão para atribuir o tipo de usuário " + roleName)
+	}
+	return nil
+}
+
+func MatchUserTypeToUid(c *gin.Context, userId string) (err error) {
+	if GetClaim(c, "Uid") == userId {
+		return nil
+	}
+
+	return CheckPermission(c, PermUserRead)
 }
@@ -0,0 +1,101 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	models "github.com/Nooksd/go-server/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const birthdayJob = "birthday"
+
+var birthdayJobHour = envInt("BIRTHDAY_JOB_HOUR", 8)
+var birthdayJobInterval = time.Minute * 15
+
+func TodaysBirthdays(ctx context.Context, now time.Time) ([]models.User, error) {
+	filter := bson.M{"active": bson.M{"$ne": false}, "pending": bson.M{"$ne": true}, "birthday": bson.M{"$gt": time.Time{}}}
+	projection := bson.M{"uid": 1, "name": 1, "birthday": 1}
+
+	cursor, err := userCollection.Find(ctx, filter, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	today := CalendarDate(now.In(CompanyLocation))
+
+	birthdays := []models.User{}
+	for _, user := range users {
+		if user.Birthday.IsZero() || user.Name == nil {
+			continue
+		}
+		if AnniversaryInYear(user.Birthday, today.Year()).Equal(today) {
+			birthdays = append(birthdays, user)
+		}
+	}
+
+	return birthdays, nil
+}
+
+func firstName(name string) string {
+	if fields := strings.Fields(name); len(fields) > 0 {
+		return fields[0]
+	}
+	return name
+}
+
+func announceBirthday(ctx context.Context, user models.User, year int) {
+	key := user.Uid + ":" + strconv.Itoa(year)
+
+	if ClaimJobRun(ctx, birthdayJob+":announce", key) {
+		err := CreateNotification(fmt.Sprintf("Hoje é aniversário de %s! Deixe seus parabéns 🎂", *user.Name), "birthday")
+		if err != nil {
+			log.Printf("Erro ao anunciar aniversário de %s: %v\n", user.Uid, err)
+			ReleaseJobRun(ctx, birthdayJob+":announce", key)
+		}
+	}
+
+	if ClaimJobRun(ctx, birthdayJob+":greeting", key) {
+		err := CreateNotification(fmt.Sprintf("Feliz aniversário, %s! 🎉 Toda a equipe deseja um ótimo dia.", firstName(*user.Name)), user.Uid)
+		if err != nil {
+			log.Printf("Erro ao enviar parabéns para %s: %v\n", user.Uid, err)
+			ReleaseJobRun(ctx, birthdayJob+":greeting", key)
+		}
+	}
+}
+
+func RunBirthdayJob(now time.Time) {
+	now = now.In(CompanyLocation)
+
+	if now.Hour() < birthdayJobHour {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	birthdays, err := TodaysBirthdays(ctx, now)
+	if err != nil {
+		log.Printf("Erro ao buscar aniversariantes: %v\n", err)
+		return
+	}
+
+	for _, user := range birthdays {
+		announceBirthday(ctx, user, now.Year())
+	}
+}
+
+func StartBirthdayJob() {
+	RunPeriodically(birthdayJob, birthdayJobInterval, RunBirthdayJob)
+}
@@ -0,0 +1,125 @@
+package helpers
+
+import (
+	"context"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	models "github.com/Nooksd/go-server/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var CompanyLocation = loadCompanyLocation()
+
+func loadCompanyLocation() *time.Location {
+	name := os.Getenv("COMPANY_TIMEZONE")
+	if name == "" {
+		name = "America/Sao_Paulo"
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		log.Printf("Fuso horário da empresa inválido (%s), usando UTC: %v\n", name, err)
+		return time.UTC
+	}
+	return location
+}
+
+func CompanyNow() time.Time {
+	return time.Now().In(CompanyLocation)
+}
+
+func CalendarDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// Datas de nascimento e admissão são datas de calendário, salvas à meia-noite UTC via CalendarDate.
+func AnniversaryInYear(date time.Time, year int) time.Time {
+	date = date.UTC()
+	day := date.Day()
+	if date.Month() == time.February && day == 29 && !isLeapYear(year) {
+		day = 28
+	}
+	return time.Date(year, date.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+func OccurrencesBetween(date time.Time, start time.Time, end time.Time) []time.Time {
+	var occurrences []time.Time
+	for year := start.Year(); year <= end.Year(); year++ {
+		occurrence := AnniversaryInYear(date, year)
+		if !occurrence.Before(start) && occurrence.Before(end) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences
+}
+
+func BirthdayCalendar(ctx context.Context, start time.Time, end time.Time, viewer Viewer) ([]models.CalendarBirthday, error) {
+	filter := bson.M{"active": bson.M{"$ne": false}, "birthday": bson.M{"$gt": time.Time{}}}
+	projection := bson.M{
+		"uid":               1,
+		"name":              1,
+		"role":              1,
+		"profilePictureUrl": 1,
+		"birthday":          1,
+		"departmentId":      1,
+		"managerId":         1,
+		"privacy":           1,
+	}
+
+	cursor, err := userCollection.Find(ctx, filter, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	start = CalendarDate(start)
+	end = CalendarDate(end)
+
+	birthdays := []models.CalendarBirthday{}
+	for _, user := range users {
+		if user.Birthday.IsZero() || user.Name == nil {
+			continue
+		}
+
+		birthdate := user.Birthday
+		if !viewer.CanSee(user.Privacy.WithDefaults().BirthdayYear, user) {
+			birthdate = MaskBirthdayYear(birthdate)
+		}
+
+		for _, date := range OccurrencesBetween(user.Birthday, start, end) {
+			birthday := models.CalendarBirthday{
+				Uid:               user.Uid,
+				Name:              *user.Name,
+				ProfilePictureUrl: user.ProfilePictureUrl,
+				Birthday:          birthdate,
+				Date:              date,
+			}
+			if user.Role != nil {
+				birthday.Role = *user.Role
+			}
+			birthdays = append(birthdays, birthday)
+		}
+	}
+
+	sort.Slice(birthdays, func(i, j int) bool {
+		if birthdays[i].Date.Equal(birthdays[j].Date) {
+			return birthdays[i].Name < birthdays[j].Name
+		}
+		return birthdays[i].Date.Before(birthdays[j].Date)
+	})
+
+	return birthdays, nil
+}
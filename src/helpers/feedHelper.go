@@ -0,0 +1,372 @@
+package helpers
+
+import (
+	"context"
+	"hash/fnv"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	models "github.com/Nooksd/go-server/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const feedRankingJob = "feed-ranking"
+
+type FeedSignals struct {
+	Age            time.Duration
+	Likes          int
+	Comments       int
+	Affinity       float64
+	SameDepartment bool
+	Boost          float64
+}
+
+type FeedRanker interface {
+	Name() string
+	Score(signals FeedSignals) float64
+}
+
+type WeightedFeedRanker struct {
+	Strategy         string
+	HalfLife         time.Duration
+	LikeWeight       float64
+	CommentWeight    float64
+	AffinityWeight   float64
+	DepartmentWeight float64
+}
+
+func (r WeightedFeedRanker) Name() string {
+	return r.Strategy
+}
+
+func (r WeightedFeedRanker) Score(signals FeedSignals) float64 {
+	relevance := 1 +
+		r.LikeWeight*math.Log1p(float64(signals.Likes)) +
+		r.CommentWeight*math.Log1p(float64(signals.Comments)) +
+		r.AffinityWeight*signals.Affinity
+
+	if signals.SameDepartment {
+		relevance += r.DepartmentWeight
+	}
+
+	decay := math.Pow(0.5, signals.Age.Hours()/r.HalfLife.Hours())
+	return relevance*decay + signals.Boost
+}
+
+var FeedRankers = map[string]FeedRanker{
+	"default": WeightedFeedRanker{
+		Strategy:         "default",
+		HalfLife:         time.Hour * 24,
+		LikeWeight:       0.5,
+		CommentWeight:    0.8,
+		AffinityWeight:   1.5,
+		DepartmentWeight: 0.5,
+	},
+	"engagement": WeightedFeedRanker{
+		Strategy:         "engagement",
+		HalfLife:         time.Hour * 48,
+		LikeWeight:       1,
+		CommentWeight:    1.5,
+		AffinityWeight:   0.5,
+		DepartmentWeight: 0.25,
+	},
+}
+
+var feedExperiment = envList("FEED_RANKERS")
+var feedWindow = time.Hour * 24 * time.Duration(envInt("FEED_RANK_WINDOW_DAYS", 14))
+var feedCandidateLimit = int64(envInt("FEED_RANK_CANDIDATES", 500))
+var feedAffinityWindow = time.Hour * 24 * 60
+var feedRefreshInterval = time.Minute
+var feedViewerCacheDuration = time.Minute * 5
+
+type feedCandidate struct {
+	id        primitive.ObjectID
+	ownerId   string
+	createdAt time.Time
+	likes     int
+	comments  int
+	boost     float64
+}
+
+type RankedPost struct {
+	Id    primitive.ObjectID
+	Score float64
+}
+
+type rankedFeed struct {
+	posts      []RankedPost
+	computedAt time.Time
+}
+
+var feedCandidates = struct {
+	sync.RWMutex
+	posts    []feedCandidate
+	loadedAt time.Time
+}{}
+
+var feedViewerCache = struct {
+	sync.Mutex
+	feeds map[string]rankedFeed
+}{feeds: map[string]rankedFeed{}}
+
+func FeedRankerFor(uid string, requested string) FeedRanker {
+	if ranker, ok := FeedRankers[strings.ToLower(requested)]; ok {
+		return ranker
+	}
+
+	var experiment []FeedRanker
+	for _, name := range feedExperiment {
+		if ranker, ok := FeedRankers[name]; ok {
+			experiment = append(experiment, ranker)
+		}
+	}
+	if len(experiment) == 0 {
+		return FeedRankers["default"]
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(uid))
+	return experiment[hash.Sum32()%uint32(len(experiment))]
+}
+
+func loadFeedCandidates(ctx context.Context, now time.Time) error {
+	filter := bson.M{"$or": []bson.M{
+		{"createdAt": bson.M{"$gte": now.Add(-feedWindow)}},
+		{"pinnedUntil": bson.M{"$gt": now}},
+	}}
+	projection := bson.M{"ownerId": 1, "createdAt": 1, "likes": 1, "comments._id": 1, "pinnedBoost": 1, "pinnedUntil": 1}
+	opts := options.Find().
+		SetProjection(projection).
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(feedCandidateLimit)
+
+	cursor, err := postCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var posts []models.Post
+	if err := cursor.All(ctx, &posts); err != nil {
+		return err
+	}
+
+	candidates := make([]feedCandidate, 0, len(posts))
+	for _, post := range posts {
+		candidate := feedCandidate{
+			id:        post.ID,
+			ownerId:   post.OwnerId,
+			createdAt: post.CreatedAt,
+			likes:     len(post.Likes),
+			comments:  len(post.Comments),
+		}
+		if post.PinnedUntil != nil && post.PinnedUntil.After(now) {
+			candidate.boost = post.PinnedBoost
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	feedCandidates.Lock()
+	feedCandidates.posts = candidates
+	feedCandidates.loadedAt = now
+	feedCandidates.Unlock()
+
+	return nil
+}
+
+func currentFeedCandidates(ctx context.Context) ([]feedCandidate, error) {
+	feedCandidates.RLock()
+	posts, loadedAt := feedCandidates.posts, feedCandidates.loadedAt
+	feedCandidates.RUnlock()
+
+	if time.Since(loadedAt) < feedRefreshInterval*2 {
+		return posts, nil
+	}
+
+	if err := loadFeedCandidates(ctx, time.Now()); err != nil {
+		return nil, err
+	}
+
+	feedCandidates.RLock()
+	defer feedCandidates.RUnlock()
+	return feedCandidates.posts, nil
+}
+
+func feedAffinity(ctx context.Context, uid string, since time.Time) (map[string]float64, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"createdAt": bson.M{"$gte": since},
+			"$or":       []bson.M{{"likes": uid}, {"comments.ownerId": uid}},
+		}},
+		{"$project": bson.M{
+			"ownerId": 1,
+			"interactions": bson.M{"$add": []interface{}{
+				bson.M{"$cond": []interface{}{bson.M{"$in": []interface{}{uid, bson.M{"$ifNull": []interface{}{"$likes", []string{}}}}}, 1, 0}},
+				bson.M{"$size": bson.M{"$filter": bson.M{
+					"input": bson.M{"$ifNull": []interface{}{"$comments", []interface{}{}}},
+					"cond":  bson.M{"$eq": []interface{}{"$$this.ownerId", uid}},
+				}}},
+			}},
+		}},
+		{"$group": bson.M{"_id": "$ownerId", "interactions": bson.M{"$sum": "$interactions"}}},
+	}
+
+	cursor, err := postCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		OwnerId      string  `bson:"_id"`
+		Interactions float64 `bson:"interactions"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	highest := 0.0
+	for _, row := range rows {
+		if row.OwnerId != uid && row.Interactions > highest {
+			highest = row.Interactions
+		}
+	}
+
+	affinity := map[string]float64{}
+	for _, row := range rows {
+		if row.OwnerId != uid && highest > 0 {
+			affinity[row.OwnerId] = row.Interactions / highest
+		}
+	}
+	return affinity, nil
+}
+
+func departmentMembers(ctx context.Context, departmentId string) (map[string]bool, error) {
+	members := map[string]bool{}
+	if departmentId == "" {
+		return members, nil
+	}
+
+	filter := bson.M{"departmentId": departmentId, "active": bson.M{"$ne": false}}
+	cursor, err := userCollection.Find(ctx, filter, options.Find().SetProjection(bson.M{"uid": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		members[user.Uid] = true
+	}
+	return members, nil
+}
+
+// RankFeed ranqueia o feed do usuário. Com at zero usa o instante atual (ou
+// o ranking em cache); com at preenchido, vindo do cursor, calcula as
+// pontuações nesse mesmo instante para que a paginação seja consistente.
+func RankFeed(ctx context.Context, viewer Viewer, ranker FeedRanker, at time.Time) ([]RankedPost, time.Time, error) {
+	key := viewer.Uid + ":" + ranker.Name()
+
+	if !at.IsZero() && (at.After(time.Now()) || time.Since(at) > feedWindow) {
+		return nil, time.Time{}, ErrInvalidCursor
+	}
+
+	feedViewerCache.Lock()
+	cached, ok := feedViewerCache.feeds[key]
+	feedViewerCache.Unlock()
+
+	if ok && at.IsZero() && time.Since(cached.computedAt) < feedViewerCacheDuration {
+		return cached.posts, cached.computedAt, nil
+	}
+	if ok && !at.IsZero() && cached.computedAt.Equal(at) {
+		return cached.posts, cached.computedAt, nil
+	}
+
+	candidates, err := currentFeedCandidates(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	now := at
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	affinity, err := feedAffinity(ctx, viewer.Uid, now.Add(-feedAffinityWindow))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	members, err := departmentMembers(ctx, viewer.DepartmentId)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	ranked := make([]RankedPost, 0, len(candidates))
+	for _, candidate := range candidates {
+		signals := FeedSignals{
+			Age:            now.Sub(candidate.createdAt),
+			Likes:          candidate.likes,
+			Comments:       candidate.comments,
+			Affinity:       affinity[candidate.ownerId],
+			SameDepartment: candidate.ownerId != viewer.Uid && members[candidate.ownerId],
+			Boost:          candidate.boost,
+		}
+		ranked = append(ranked, RankedPost{Id: candidate.id, Score: ranker.Score(signals)})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score == ranked[j].Score {
+			return ranked[i].Id.Hex() > ranked[j].Id.Hex()
+		}
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	if !at.IsZero() {
+		return ranked, now, nil
+	}
+
+	feedViewerCache.Lock()
+	for cachedKey, feed := range feedViewerCache.feeds {
+		if time.Since(feed.computedAt) > feedViewerCacheDuration {
+			delete(feedViewerCache.feeds, cachedKey)
+		}
+	}
+	feedViewerCache.feeds[key] = rankedFeed{posts: ranked, computedAt: now}
+	feedViewerCache.Unlock()
+
+	return ranked, now, nil
+}
+
+func InvalidateFeedRanking() {
+	feedCandidates.Lock()
+	feedCandidates.loadedAt = time.Time{}
+	feedCandidates.Unlock()
+
+	feedViewerCache.Lock()
+	feedViewerCache.feeds = map[string]rankedFeed{}
+	feedViewerCache.Unlock()
+}
+
+func refreshFeedCandidates(now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := loadFeedCandidates(ctx, now); err != nil {
+		log.Printf("Erro ao atualizar ranking do feed: %v\n", err)
+	}
+}
+
+func StartFeedRankingJob() {
+	RunPeriodically(feedRankingJob, feedRefreshInterval, refreshFeedCandidates)
+}
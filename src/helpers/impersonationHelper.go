@@ -0,0 +1,35 @@
+package helpers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ImpersonationBlockedRoutes = map[string]bool{
+	"PUT /users/me/password":            true,
+	"PUT /users/update/:userId":         true,
+	"POST /users/me/mfa/setup":          true,
+	"POST /users/me/mfa/enable":         true,
+	"POST /users/me/mfa/disable":        true,
+	"POST /users/me/mfa/recovery-codes": true,
+	"PUT /users/me/privacy":             true,
+	"POST /avatar/upload/:userId":       true,
+	"POST /auth/logout-all":             true,
+	"POST /notification/register-token": true,
+	"POST /validation/create":           true,
+	"PUT /post/edit/:postId":            true,
+}
+
+func Impersonator(claims jwt.MapClaims) string {
+	impersonator, _ := claims["ImpersonatorUid"].(string)
+	return impersonator
+}
+
+func ImpersonationBlocked(method string, route string) bool {
+	if method == http.MethodDelete || strings.HasPrefix(route, "/admin/") {
+		return true
+	}
+	return ImpersonationBlockedRoutes[method+" "+route]
+}
@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	"time"
+
+	"github.com/Nooksd/go-server/src/ldapsync"
+)
+
+var LDAP = ldapsync.Config{
+	URL:                envString("LDAP_URL", ""),
+	BindDN:             envString("LDAP_BIND_DN", ""),
+	BindPassword:       envString("LDAP_BIND_PASSWORD", ""),
+	BaseDN:             envString("LDAP_BASE_DN", ""),
+	UserFilter:         envString("LDAP_USER_FILTER", "(&(objectClass=person)(mail=*))"),
+	StartTLS:           envBool("LDAP_START_TLS", false),
+	InsecureSkipVerify: envBool("LDAP_INSECURE_SKIP_VERIFY", false),
+	PageSize:           envInt("LDAP_PAGE_SIZE", 500),
+	Attributes: ldapsync.Attributes{
+		Email:   envString("LDAP_ATTR_EMAIL", "mail"),
+		Name:    envString("LDAP_ATTR_NAME", "cn"),
+		Role:    envString("LDAP_ATTR_ROLE", "title"),
+		Phone:   envString("LDAP_ATTR_PHONE", "telephoneNumber"),
+		Manager: envString("LDAP_ATTR_MANAGER", "manager"),
+	},
+	DefaultUserType: envString("LDAP_DEFAULT_USER_TYPE", "USER"),
+	Deactivate:      envBool("LDAP_SYNC_DEACTIVATE", true),
+	Interval:        time.Duration(envInt("LDAP_SYNC_INTERVAL_MINUTES", 0)) * time.Minute,
+}
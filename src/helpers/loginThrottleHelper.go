@@ -0,0 +1,191 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	models "github.com/Nooksd/go-server/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LoginThrottlePolicy struct {
+	MaxAccountAttempts int
+	MaxIPAttempts      int
+	BaseLockout        time.Duration
+	MaxLockout         time.Duration
+	Window             time.Duration
+}
+
+var DefaultLoginThrottlePolicy = LoginThrottlePolicy{
+	MaxAccountAttempts: envInt("LOGIN_MAX_ATTEMPTS", 5),
+	MaxIPAttempts:      envInt("LOGIN_MAX_IP_ATTEMPTS", 20),
+	BaseLockout:        time.Duration(envInt("LOGIN_BASE_LOCKOUT_SECONDS", 30)) * time.Second,
+	MaxLockout:         time.Duration(envInt("LOGIN_MAX_LOCKOUT_SECONDS", 3600)) * time.Second,
+	Window:             time.Duration(envInt("LOGIN_ATTEMPT_WINDOW_SECONDS", 86400)) * time.Second,
+}
+
+var loginAttemptCollection = database.OpenCollection(database.Client, "loginAttempts")
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := loginAttemptCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.M{"key": 1}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.M{"expiresAt": 1}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de tentativas de login: %v\n", err)
+	}
+}
+
+func accountKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func (p LoginThrottlePolicy) lockoutFor(failures int, max int) time.Duration {
+	if failures < max {
+		return 0
+	}
+
+	lockout := p.BaseLockout
+	for i := max; i < failures && lockout < p.MaxLockout; i++ {
+		lockout *= 2
+	}
+
+	if lockout > p.MaxLockout {
+		lockout = p.MaxLockout
+	}
+	return lockout
+}
+
+func CheckLoginAllowed(email string, ip string) time.Duration {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"key":         bson.M{"$in": []string{accountKey(email), ipKey(ip)}},
+		"lockedUntil": bson.M{"$gt": now},
+	}
+
+	cursor, err := loginAttemptCollection.Find(ctx, filter)
+	if err != nil {
+		log.Printf("Erro ao verificar bloqueio de login: %v\n", err)
+		return 0
+	}
+
+	var attempts []models.LoginAttempt
+	if err := cursor.All(ctx, &attempts); err != nil {
+		log.Printf("Erro ao processar bloqueio de login: %v\n", err)
+		return 0
+	}
+
+	var retryAfter time.Duration
+	for _, attempt := range attempts {
+		if wait := attempt.LockedUntil.Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	return retryAfter
+}
+
+func registerFailure(ctx context.Context, key string, max int) (models.LoginAttempt, time.Duration, error) {
+	now := time.Now()
+
+	var attempt models.LoginAttempt
+	err := loginAttemptCollection.FindOneAndUpdate(
+		ctx,
+		bson.M{"key": key},
+		bson.M{
+			"$inc": bson.M{"failures": 1},
+			"$set": bson.M{"lastFailureAt": now, "expiresAt": now.Add(DefaultLoginThrottlePolicy.Window)},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&attempt)
+	if err != nil {
+		return attempt, 0, err
+	}
+
+	lockout := DefaultLoginThrottlePolicy.lockoutFor(attempt.Failures, max)
+	if lockout == 0 {
+		return attempt, 0, nil
+	}
+
+	_, err = loginAttemptCollection.UpdateOne(
+		ctx,
+		bson.M{"key": key},
+		bson.M{"$set": bson.M{"lockedUntil": now.Add(lockout)}},
+	)
+	return attempt, lockout, err
+}
+
+func RegisterLoginFailure(email string, ip string, userId string) time.Duration {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	policy := DefaultLoginThrottlePolicy
+
+	attempt, accountLockout, err := registerFailure(ctx, accountKey(email), policy.MaxAccountAttempts)
+	if err != nil {
+		log.Printf("Erro ao registrar falha de login: %v\n", err)
+	}
+
+	_, ipLockout, err := registerFailure(ctx, ipKey(ip), policy.MaxIPAttempts)
+	if err != nil {
+		log.Printf("Erro ao registrar falha de login por IP: %v\n", err)
+	}
+
+	if userId != "" && attempt.Failures == policy.MaxAccountAttempts {
+		CreateNotification(
+			fmt.Sprintf(
+				"Sua conta foi bloqueada temporariamente após %d tentativas de login sem sucesso (IP %s). Se não foi você, altere sua senha.",
+				attempt.Failures,
+				ip,
+			),
+			userId)
+	}
+
+	if ipLockout > accountLockout {
+		return ipLockout
+	}
+	return accountLockout
+}
+
+func ResetLoginFailures(email string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := loginAttemptCollection.DeleteOne(ctx, bson.M{"key": accountKey(email)})
+	if err != nil {
+		log.Printf("Erro ao limpar tentativas de login: %v\n", err)
+	}
+}
+
+func UnlockLogin(email string, ip string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	keys := []string{accountKey(email)}
+	if ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+
+	result, err := loginAttemptCollection.DeleteMany(ctx, bson.M{"key": bson.M{"$in": keys}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,131 @@
+package helpers
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+)
+
+type Mailer interface {
+	Send(to string, subject string, body string) error
+}
+
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(to string, subject string, body string) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	message := strings.Join([]string{
+		"From: " + m.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"UTF-8\"",
+		"",
+		body,
+	}, "\r\n")
+
+	err := smtp.SendMail(m.Host+":"+m.Port, auth, m.From, []string{to}, []byte(message))
+	if err != nil {
+		log.Printf("Erro ao enviar email para %s: %v\n", to, err)
+		return err
+	}
+
+	return nil
+}
+
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// LogMailer só registra destinatário e assunto; o corpo pode conter tokens
+// de convite ou redefinição de senha e nunca é gravado no log.
+type LogMailer struct{}
+
+func (m LogMailer) Send(to string, subject string, body string) error {
+	log.Printf("Email para %s: %s (corpo omitido, %d bytes)\n", to, subject, len(body))
+	return nil
+}
+
+// MemoryMailer guarda as mensagens enviadas e serve apenas para testes.
+type MemoryMailer struct {
+	mu   sync.Mutex
+	Sent []MailMessage
+}
+
+func (m *MemoryMailer) Send(to string, subject string, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Sent = append(m.Sent, MailMessage{To: to, Subject: subject, Body: body})
+	return nil
+}
+
+func (m *MemoryMailer) Last() (MailMessage, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.Sent) == 0 {
+		return MailMessage{}, false
+	}
+	return m.Sent[len(m.Sent)-1], true
+}
+
+func NewMailer() Mailer {
+	driver := strings.ToLower(os.Getenv("MAIL_DRIVER"))
+	if driver == "log" {
+		log.Println("MAIL_DRIVER=log, emails serão apenas registrados no log sem o corpo")
+		return LogMailer{}
+	}
+	if driver != "" && driver != "smtp" {
+		log.Printf("MAIL_DRIVER inválido: %s, envio de emails desativado\n", driver)
+		return nil
+	}
+
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		log.Println("SMTP_HOST não definido, envio de emails desativado (use MAIL_DRIVER=log em desenvolvimento)")
+		return nil
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = os.Getenv("SMTP_USER")
+	}
+
+	return &SMTPMailer{
+		Host:     host,
+		Port:     port,
+		Username: os.Getenv("SMTP_USER"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     from,
+	}
+}
+
+var DefaultMailer Mailer = NewMailer()
+
+func SendMail(to string, subject string, body string) error {
+	if DefaultMailer == nil {
+		return fmt.Errorf("mailer não configurado")
+	}
+	return DefaultMailer.Send(to, subject, body)
+}
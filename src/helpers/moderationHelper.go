@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrPostBlocked = errors.New("o post contém termos não permitidos")
+
+var blockedTerms = envList("POST_BLOCKED_TERMS")
+
+func normalizeModerationText(text string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, text)
+
+	return " " + strings.Join(strings.Fields(normalized), " ") + " "
+}
+
+func ModeratePost(text string, hashtags []string) error {
+	if len(blockedTerms) == 0 {
+		return nil
+	}
+
+	content := normalizeModerationText(text + " " + strings.Join(hashtags, " "))
+
+	for _, term := range blockedTerms {
+		if strings.Contains(content, normalizeModerationText(term)) {
+			return ErrPostBlocked
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package helpers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	models "github.com/Nooksd/go-server/src/models"
+	"github.com/Nooksd/go-server/src/oidc"
+)
+
+type OIDCConfig struct {
+	Issuer          string
+	ClientId        string
+	ClientSecret    string
+	RedirectURL     string
+	Scopes          string
+	AutoProvision   bool
+	RequireVerified bool
+	DefaultUserType string
+	AllowedDomains  []string
+}
+
+var OIDC = OIDCConfig{
+	Issuer:          strings.TrimSuffix(envString("OIDC_ISSUER", ""), "/"),
+	ClientId:        envString("OIDC_CLIENT_ID", ""),
+	ClientSecret:    envString("OIDC_CLIENT_SECRET", ""),
+	RedirectURL:     envString("OIDC_REDIRECT_URL", ""),
+	Scopes:          envString("OIDC_SCOPES", "openid email profile"),
+	AutoProvision:   envBool("OIDC_AUTO_PROVISION", true),
+	RequireVerified: envBool("OIDC_REQUIRE_EMAIL_VERIFIED", true),
+	DefaultUserType: envString("OIDC_DEFAULT_USER_TYPE", "USER"),
+	AllowedDomains:  envList("OIDC_ALLOWED_DOMAINS"),
+}
+
+var ErrOIDCDisabled = oidc.ErrDisabled
+var ErrOIDCInvalidToken = oidc.ErrInvalidToken
+
+var oidcStateDuration = time.Minute * 10
+
+var oidcProvider = &oidc.Provider{
+	Issuer:            OIDC.Issuer,
+	ClientId:          OIDC.ClientId,
+	ClientSecret:      OIDC.ClientSecret,
+	RedirectURL:       OIDC.RedirectURL,
+	Scopes:            OIDC.Scopes,
+	MetadataDuration:  time.Hour,
+	KeyReloadInterval: signingKeyReloadInterval,
+}
+
+func envList(name string) []string {
+	var values []string
+	for _, value := range strings.Split(envString(name, ""), ",") {
+		if value = strings.TrimSpace(strings.ToLower(value)); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (config OIDCConfig) Enabled() bool {
+	return config.Issuer != "" && config.ClientId != "" && config.RedirectURL != ""
+}
+
+func (config OIDCConfig) DomainAllowed(email string) bool {
+	if len(config.AllowedDomains) == 0 {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := strings.ToLower(email[at+1:])
+	for _, allowed := range config.AllowedDomains {
+		if domain == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func NewOIDCState(ip string) (state string, record models.OIDCState) {
+	state = NewTokenId()
+	now := time.Now()
+
+	return state, models.OIDCState{
+		StateHash:    HashToken(state),
+		CodeVerifier: NewTokenId(),
+		Nonce:        NewTokenId(),
+		IP:           ip,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(oidcStateDuration),
+	}
+}
+
+func OIDCAuthorizationURL(ctx context.Context, state string, record models.OIDCState) (string, error) {
+	return oidcProvider.AuthorizationURL(ctx, state, record.Nonce, record.CodeVerifier)
+}
+
+func ExchangeOIDCCode(ctx context.Context, code string, verifier string) (string, error) {
+	return oidcProvider.ExchangeCode(ctx, code, verifier)
+}
+
+func VerifyOIDCIdToken(ctx context.Context, idToken string, nonce string) (models.OIDCClaims, error) {
+	return oidcProvider.VerifyIdToken(ctx, idToken, nonce)
+}
@@ -0,0 +1,99 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const DefaultPageLimit = 20
+const MaxPageLimit = 100
+
+var ErrInvalidCursor = errors.New("cursor inválido")
+
+type PageCursor struct {
+	Value string             `json:"v"`
+	Id    primitive.ObjectID `json:"id"`
+	At    *time.Time         `json:"at,omitempty"`
+}
+
+func ParsePageLimit(value string) int {
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return limit
+}
+
+func EncodeCursor(value string, id primitive.ObjectID) string {
+	data, _ := json.Marshal(PageCursor{Value: value, Id: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(cursor string) (PageCursor, error) {
+	var decoded PageCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return decoded, ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Id.IsZero() {
+		return decoded, ErrInvalidCursor
+	}
+
+	return decoded, nil
+}
+
+func EncodeTimeCursor(value time.Time, id primitive.ObjectID) string {
+	return EncodeCursor(value.UTC().Format(time.RFC3339Nano), id)
+}
+
+// EncodeScoreCursor guarda também o instante em que as pontuações foram
+// calculadas, para que as páginas seguintes usem a mesma referência.
+func EncodeScoreCursor(score float64, at time.Time, id primitive.ObjectID) string {
+	at = at.UTC()
+	data, _ := json.Marshal(PageCursor{Value: strconv.FormatFloat(score, 'g', -1, 64), Id: id, At: &at})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (cursor PageCursor) Score() (float64, time.Time, error) {
+	score, err := strconv.ParseFloat(cursor.Value, 64)
+	if err != nil || cursor.At == nil {
+		return 0, time.Time{}, ErrInvalidCursor
+	}
+	return score, *cursor.At, nil
+}
+
+func (cursor PageCursor) Time() (time.Time, error) {
+	value, err := time.Parse(time.RFC3339Nano, cursor.Value)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return value, nil
+}
+
+func KeysetFilter(field string, value interface{}, id primitive.ObjectID, descending bool) bson.M {
+	operator := "$gt"
+	if descending {
+		operator = "$lt"
+	}
+
+	return bson.M{"$or": []bson.M{
+		{field: bson.M{operator: value}},
+		{field: value, "_id": bson.M{operator: id}},
+	}}
+}
+
+func EscapeRegex(value string) string {
+	return regexp.QuoteMeta(value)
+}
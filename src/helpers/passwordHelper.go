@@ -0,0 +1,108 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	models "github.com/Nooksd/go-server/src/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	HistorySize   int
+}
+
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func envBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func LoadPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:     envInt("PASSWORD_MIN_LENGTH", 8),
+		RequireUpper:  envBool("PASSWORD_REQUIRE_UPPER", true),
+		RequireLower:  envBool("PASSWORD_REQUIRE_LOWER", true),
+		RequireDigit:  envBool("PASSWORD_REQUIRE_DIGIT", true),
+		RequireSymbol: envBool("PASSWORD_REQUIRE_SYMBOL", false),
+		HistorySize:   envInt("PASSWORD_HISTORY_SIZE", 5),
+	}
+}
+
+var DefaultPasswordPolicy = LoadPasswordPolicy()
+
+func (p PasswordPolicy) Validate(password string, user models.User) error {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("a senha deve ter pelo menos %d caracteres", p.MinLength)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsDigit(char):
+			hasDigit = true
+		case unicode.IsPunct(char) || unicode.IsSymbol(char):
+			hasSymbol = true
+		}
+	}
+
+	if p.RequireUpper && !hasUpper {
+		return errors.New("a senha deve conter uma letra maiúscula")
+	}
+	if p.RequireLower && !hasLower {
+		return errors.New("a senha deve conter uma letra minúscula")
+	}
+	if p.RequireDigit && !hasDigit {
+		return errors.New("a senha deve conter um número")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		return errors.New("a senha deve conter um caractere especial")
+	}
+
+	lowered := strings.ToLower(password)
+	if user.Email != nil && *user.Email != "" && lowered == strings.ToLower(*user.Email) {
+		return errors.New("a senha não pode ser igual ao email")
+	}
+	if user.Name != nil && *user.Name != "" && lowered == strings.ToLower(*user.Name) {
+		return errors.New("a senha não pode ser igual ao nome")
+	}
+
+	hashes := user.PasswordHistory
+	if user.Password != nil {
+		hashes = append([]string{*user.Password}, hashes...)
+	}
+	if len(hashes) > p.HistorySize+1 {
+		hashes = hashes[:p.HistorySize+1]
+	}
+
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return errors.New("a senha não pode ser igual às últimas senhas utilizadas")
+		}
+	}
+
+	return nil
+}
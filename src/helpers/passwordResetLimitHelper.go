@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	models "github.com/Nooksd/go-server/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PasswordResetLimit struct {
+	MaxAccountRequests int
+	MaxIPRequests      int
+	Window             time.Duration
+}
+
+var PasswordResetRateLimit = PasswordResetLimit{
+	MaxAccountRequests: envInt("PASSWORD_RESET_MAX_REQUESTS", 3),
+	MaxIPRequests:      envInt("PASSWORD_RESET_MAX_IP_REQUESTS", 20),
+	Window:             time.Duration(envInt("PASSWORD_RESET_WINDOW_SECONDS", 3600)) * time.Second,
+}
+
+var passwordResetRequestCollection = database.OpenCollection(database.Client, "passwordResetRequests")
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := passwordResetRequestCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.M{"key": 1}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.M{"expiresAt": 1}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de solicitações de redefinição de senha: %v\n", err)
+	}
+}
+
+func countPasswordResetRequest(ctx context.Context, key string, window time.Duration) (models.PasswordResetRequestCount, error) {
+	now := time.Now()
+
+	_, err := passwordResetRequestCollection.DeleteOne(ctx, bson.M{"key": key, "expiresAt": bson.M{"$lte": now}})
+	if err != nil {
+		return models.PasswordResetRequestCount{}, err
+	}
+
+	var count models.PasswordResetRequestCount
+	err = passwordResetRequestCollection.FindOneAndUpdate(
+		ctx,
+		bson.M{"key": key},
+		bson.M{
+			"$inc":         bson.M{"requests": 1},
+			"$set":         bson.M{"lastRequestAt": now},
+			"$setOnInsert": bson.M{"expiresAt": now.Add(window)},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&count)
+	return count, err
+}
+
+func (l PasswordResetLimit) Allow(email string, ip string) time.Duration {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	limits := map[string]int{
+		accountKey(email): l.MaxAccountRequests,
+		ipKey(ip):         l.MaxIPRequests,
+	}
+
+	var retryAfter time.Duration
+	for key, max := range limits {
+		count, err := countPasswordResetRequest(ctx, key, l.Window)
+		if err != nil {
+			log.Printf("Erro ao registrar solicitação de redefinição %s: %v\n", key, err)
+			continue
+		}
+		if count.Requests > max {
+			if wait := time.Until(count.ExpiresAt); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+	}
+	return retryAfter
+}
@@ -0,0 +1,230 @@
+package helpers
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	models "github.com/Nooksd/go-server/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	PermUserCreate            = "user:create"
+	PermUserRead              = "user:read"
+	PermUserUpdate            = "user:update"
+	PermUserUnlock            = "user:unlock"
+	PermUserDeactivate        = "user:deactivate"
+	PermSessionManage         = "session:manage"
+	PermMissionCreate         = "mission:create"
+	PermMissionComplete       = "mission:complete"
+	PermMissionDelete         = "mission:delete"
+	PermValidationReview      = "validation:review"
+	PermPostCreate            = "post:create"
+	PermPostModerate          = "post:moderate"
+	PermNotificationBroadcast = "notification:broadcast"
+	PermNotificationDelete    = "notification:delete"
+	PermRoleManage            = "role:manage"
+	PermServiceAccountManage  = "serviceaccount:manage"
+	PermDepartmentManage      = "department:manage"
+	PermSettingsManage        = "settings:manage"
+	PermAuditRead             = "audit:read"
+	PermDirectorySync         = "directory:sync"
+	PermUserImpersonate       = "user:impersonate"
+)
+
+var Permissions = []string{
+	PermUserCreate,
+	PermUserRead,
+	PermUserUpdate,
+	PermUserUnlock,
+	PermUserDeactivate,
+	PermSessionManage,
+	PermMissionCreate,
+	PermMissionComplete,
+	PermMissionDelete,
+	PermValidationReview,
+	PermPostCreate,
+	PermPostModerate,
+	PermNotificationBroadcast,
+	PermNotificationDelete,
+	PermRoleManage,
+	PermServiceAccountManage,
+	PermDepartmentManage,
+	PermSettingsManage,
+	PermAuditRead,
+	PermDirectorySync,
+	PermUserImpersonate,
+}
+
+var DefaultRoles = []models.Role{
+	{Name: "ADMIN", Description: "Acesso total", Permissions: []string{"*"}, RequireMfa: envBool("MFA_REQUIRE_ADMIN", false)},
+	{Name: "USER", Description: "Funcionário", Permissions: []string{PermUserRead}},
+	{Name: "MODERATOR", Description: "Moderação do feed", Permissions: []string{PermUserRead, PermPostModerate}},
+	{Name: "HR", Description: "Recursos humanos", Permissions: []string{PermUserRead, PermUserCreate, PermUserUpdate, PermNotificationBroadcast, PermDepartmentManage}},
+}
+
+var roleCollection = database.OpenCollection(database.Client, "roles")
+
+var roleCacheDuration = time.Minute
+
+var roleCache = struct {
+	sync.RWMutex
+	roles    map[string]models.Role
+	loadedAt time.Time
+}{}
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := roleCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.M{"name": 1},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índice de cargos: %v\n", err)
+	}
+
+	for _, role := range DefaultRoles {
+		now := time.Now()
+		_, err := roleCollection.UpdateOne(
+			ctx,
+			bson.M{"name": role.Name},
+			bson.M{"$setOnInsert": bson.M{
+				"_id":         primitive.NewObjectID(),
+				"name":        role.Name,
+				"description": role.Description,
+				"permissions": role.Permissions,
+				"requireMfa":  role.RequireMfa,
+				"createdAt":   now,
+				"updatedAt":   now,
+			}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			log.Printf("Erro ao criar cargo padrão %s: %v\n", role.Name, err)
+		}
+	}
+}
+
+func loadRoles() map[string]models.Role {
+	roleCache.RLock()
+	if roleCache.roles != nil && time.Since(roleCache.loadedAt) < roleCacheDuration {
+		roles := roleCache.roles
+		roleCache.RUnlock()
+		return roles
+	}
+	roleCache.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := roleCollection.Find(ctx, bson.M{})
+	if err != nil {
+		log.Printf("Erro ao carregar cargos: %v\n", err)
+		return cachedRoles()
+	}
+
+	var list []models.Role
+	if err := cursor.All(ctx, &list); err != nil {
+		log.Printf("Erro ao processar cargos: %v\n", err)
+		return cachedRoles()
+	}
+
+	roles := make(map[string]models.Role, len(list))
+	for _, role := range list {
+		roles[role.Name] = role
+	}
+
+	roleCache.Lock()
+	roleCache.roles = roles
+	roleCache.loadedAt = time.Now()
+	roleCache.Unlock()
+
+	return roles
+}
+
+func cachedRoles() map[string]models.Role {
+	roleCache.RLock()
+	defer roleCache.RUnlock()
+
+	if roleCache.roles != nil {
+		return roleCache.roles
+	}
+
+	roles := make(map[string]models.Role, len(DefaultRoles))
+	for _, role := range DefaultRoles {
+		roles[role.Name] = role
+	}
+	return roles
+}
+
+func InvalidateRoleCache() {
+	roleCache.Lock()
+	roleCache.roles = nil
+	roleCache.Unlock()
+}
+
+func GetRole(name string) (models.Role, bool) {
+	role, ok := loadRoles()[name]
+	return role, ok
+}
+
+func RoleExists(name string) bool {
+	_, ok := GetRole(name)
+	return ok
+}
+
+func RoleRequiresMfa(name string) bool {
+	role, ok := GetRole(name)
+	return ok && role.RequireMfa
+}
+
+func IsKnownPermission(permission string) bool {
+	if permission == "*" {
+		return true
+	}
+
+	for _, known := range Permissions {
+		if known == permission {
+			return true
+		}
+		if strings.HasSuffix(permission, ":*") && strings.HasPrefix(known, strings.TrimSuffix(permission, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+func permissionMatches(granted string, permission string) bool {
+	if granted == "*" || granted == permission {
+		return true
+	}
+
+	if strings.HasSuffix(granted, ":*") {
+		return strings.HasPrefix(permission, strings.TrimSuffix(granted, "*"))
+	}
+
+	return false
+}
+
+func HasPermission(userType string, permission string) bool {
+	role, ok := GetRole(userType)
+	if !ok {
+		return false
+	}
+
+	for _, granted := range role.Permissions {
+		if permissionMatches(granted, permission) {
+			return true
+		}
+	}
+	return false
+}
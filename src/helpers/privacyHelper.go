@@ -0,0 +1,122 @@
+package helpers
+
+import (
+	"context"
+	"time"
+
+	models "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Viewer struct {
+	Uid          string
+	DepartmentId string
+	ManagerId    string
+	Colleague    bool
+	Privileged   bool
+}
+
+func ViewerFromContext(c *gin.Context) Viewer {
+	userClaims, exists := c.Get("user")
+	if !exists {
+		return Viewer{}
+	}
+
+	claims, ok := userClaims.(jwt.MapClaims)
+	if !ok {
+		return Viewer{}
+	}
+
+	viewer := Viewer{
+		Privileged: HasClaimsPermission(claims, PermUserUpdate),
+	}
+
+	if IsServiceAccount(claims) {
+		return viewer
+	}
+
+	viewer.Uid, _ = claims["Uid"].(string)
+	viewer.Colleague = viewer.Uid != ""
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var user models.User
+	projection := bson.M{"departmentId": 1, "managerId": 1}
+	if err := userCollection.FindOne(ctx, bson.M{"uid": viewer.Uid}, options.FindOne().SetProjection(projection)).Decode(&user); err == nil {
+		viewer.DepartmentId = user.DepartmentId
+		viewer.ManagerId = user.ManagerId
+	}
+
+	return viewer
+}
+
+func (v Viewer) IsSelf(target models.User) bool {
+	return v.Uid != "" && v.Uid == target.Uid
+}
+
+func (v Viewer) IsTeammate(target models.User) bool {
+	if v.Uid == "" {
+		return false
+	}
+	if v.DepartmentId != "" && v.DepartmentId == target.DepartmentId {
+		return true
+	}
+	return target.ManagerId == v.Uid || (v.ManagerId != "" && v.ManagerId == target.Uid)
+}
+
+func (v Viewer) CanSee(level string, target models.User) bool {
+	if v.Privileged || v.IsSelf(target) {
+		return true
+	}
+
+	switch level {
+	case models.VisibilityPublic:
+		return true
+	case models.VisibilityColleagues:
+		return v.Colleague
+	case models.VisibilityTeam:
+		return v.IsTeammate(target)
+	default:
+		return false
+	}
+}
+
+func MaskBirthdayYear(date time.Time) time.Time {
+	if date.IsZero() {
+		return date
+	}
+	date = date.UTC()
+	return time.Date(1, date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func ApplyPrivacy(user *models.User, viewer Viewer) {
+	user.Password = nil
+
+	if viewer.Privileged || viewer.IsSelf(*user) {
+		settings := user.Privacy.WithDefaults()
+		user.Privacy = &settings
+		return
+	}
+
+	settings := user.Privacy.WithDefaults()
+	user.Privacy = nil
+
+	if !viewer.CanSee(settings.Email, *user) {
+		user.Email = nil
+	}
+	if !viewer.CanSee(settings.Phone, *user) {
+		user.PhoneNumber = nil
+	}
+	if !viewer.CanSee(settings.BirthdayYear, *user) {
+		user.Birthday = MaskBirthdayYear(user.Birthday)
+	}
+	if !viewer.CanSee(settings.Socials, *user) {
+		user.LinkedinURL = nil
+		user.FacebookURL = nil
+		user.InstagramURL = nil
+	}
+}
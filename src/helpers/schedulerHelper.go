@@ -0,0 +1,74 @@
+package helpers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	models "github.com/Nooksd/go-server/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var jobRunCollection = database.OpenCollection(database.Client, "jobRuns")
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := jobRunCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "job", Value: 1}, {Key: "key", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de execuções agendadas: %v\n", err)
+	}
+}
+
+func ClaimJobRun(ctx context.Context, job string, key string) bool {
+	_, err := jobRunCollection.InsertOne(ctx, models.JobRun{
+		ID:        primitive.NewObjectID(),
+		Job:       job,
+		Key:       key,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		if !mongo.IsDuplicateKeyError(err) {
+			log.Printf("Erro ao registrar execução de %s (%s): %v\n", job, key, err)
+		}
+		return false
+	}
+	return true
+}
+
+func ReleaseJobRun(ctx context.Context, job string, key string) {
+	_, err := jobRunCollection.DeleteOne(ctx, bson.M{"job": job, "key": key})
+	if err != nil {
+		log.Printf("Erro ao liberar execução de %s (%s): %v\n", job, key, err)
+	}
+}
+
+func RunPeriodically(name string, interval time.Duration, job func(now time.Time)) {
+	run := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Erro na tarefa agendada %s: %v\n", name, r)
+			}
+		}()
+		job(time.Now())
+	}
+
+	go func() {
+		run()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			run()
+		}
+	}()
+}
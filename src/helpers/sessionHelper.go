@@ -0,0 +1,225 @@
+package helpers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	models "github.com/Nooksd/go-server/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var sessionCollection = database.OpenCollection(database.Client, "sessions")
+
+var ErrSessionNotFound = errors.New("sessão não encontrada")
+var ErrSessionRevoked = errors.New("sessão revogada")
+var ErrSessionReused = errors.New("refresh token reutilizado, sessão revogada")
+
+func init() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := sessionCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.M{"userId": 1}},
+		{Keys: bson.M{"expiresAt": 1}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de sessões: %v\n", err)
+	}
+}
+
+func NewTokenId() string {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		log.Panic(err)
+	}
+	return hex.EncodeToString(bytes)
+}
+
+func CreateSession(userId string, userAgent string, ip string) (models.Session, error) {
+	return createSession(models.Session{UserId: userId, UserAgent: userAgent, IP: ip}, RefreshTokenDuration)
+}
+
+func CreateImpersonationSession(userId string, impersonatorId string, userAgent string, ip string) (models.Session, error) {
+	session := models.Session{UserId: userId, ImpersonatorId: impersonatorId, UserAgent: userAgent, IP: ip}
+	return createSession(session, ImpersonationTokenDuration)
+}
+
+func createSession(session models.Session, duration time.Duration) (models.Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+
+	session.ID = primitive.NewObjectID()
+	session.TokenId = NewTokenId()
+	session.CreatedAt = now
+	session.LastUsedAt = now
+	session.ExpiresAt = now.Add(duration)
+
+	_, err := sessionCollection.InsertOne(ctx, session)
+	if err != nil {
+		log.Printf("Erro ao criar sessão: %v\n", err)
+		return models.Session{}, err
+	}
+
+	return session, nil
+}
+
+func RotateSession(sessionId string, tokenId string, ip string) (models.Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	id, err := primitive.ObjectIDFromHex(sessionId)
+	if err != nil {
+		return models.Session{}, ErrSessionNotFound
+	}
+
+	var session models.Session
+	err = sessionCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&session)
+	if err != nil {
+		return models.Session{}, ErrSessionNotFound
+	}
+
+	if session.Revoked {
+		return models.Session{}, ErrSessionRevoked
+	}
+
+	if session.TokenId != tokenId {
+		RevokeSession(sessionId, "reuse")
+		return models.Session{}, ErrSessionReused
+	}
+
+	now := time.Now()
+	newTokenId := NewTokenId()
+
+	filter := bson.M{"_id": id, "tokenId": tokenId, "revoked": false}
+	update := bson.M{
+		"$set": bson.M{
+			"tokenId":    newTokenId,
+			"ip":         ip,
+			"lastUsedAt": now,
+			"expiresAt":  now.Add(RefreshTokenDuration),
+		},
+	}
+
+	result, err := sessionCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Erro ao rotacionar sessão: %v\n", err)
+		return models.Session{}, err
+	}
+
+	if result.ModifiedCount == 0 {
+		RevokeSession(sessionId, "reuse")
+		return models.Session{}, ErrSessionReused
+	}
+
+	session.TokenId = newTokenId
+	session.IP = ip
+	session.LastUsedAt = now
+	session.ExpiresAt = now.Add(RefreshTokenDuration)
+
+	return session, nil
+}
+
+func RevokeSession(sessionId string, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	id, err := primitive.ObjectIDFromHex(sessionId)
+	if err != nil {
+		return ErrSessionNotFound
+	}
+
+	result, err := sessionCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "revoked": false},
+		bson.M{"$set": bson.M{"revoked": true, "revokedAt": time.Now(), "revokedReason": reason}},
+	)
+	if err != nil {
+		log.Printf("Erro ao revogar sessão: %v\n", err)
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func RevokeUserSessions(userId string, exceptSessionId string, reason string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"userId": userId, "revoked": false}
+
+	if exceptId, err := primitive.ObjectIDFromHex(exceptSessionId); err == nil {
+		filter["_id"] = bson.M{"$ne": exceptId}
+	}
+
+	result, err := sessionCollection.UpdateMany(
+		ctx,
+		filter,
+		bson.M{"$set": bson.M{"revoked": true, "revokedAt": time.Now(), "revokedReason": reason}},
+	)
+	if err != nil {
+		log.Printf("Erro ao revogar sessões do usuário %s: %v\n", userId, err)
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func GetSession(sessionId string) (models.Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	id, err := primitive.ObjectIDFromHex(sessionId)
+	if err != nil {
+		return models.Session{}, ErrSessionNotFound
+	}
+
+	var session models.Session
+	err = sessionCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&session)
+	if err != nil {
+		return models.Session{}, ErrSessionNotFound
+	}
+
+	return session, nil
+}
+
+func GetUserSessions(userId string) ([]models.Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"userId": userId, "revoked": false, "expiresAt": bson.M{"$gt": time.Now()}}
+
+	cursor, err := sessionCollection.Find(ctx, filter, options.Find().SetSort(bson.M{"lastUsedAt": -1}))
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := []models.Session{}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func IsSessionActive(sessionId string) bool {
+	session, err := GetSession(sessionId)
+	if err != nil {
+		return false
+	}
+
+	return !session.Revoked && session.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,548 @@
+package helpers
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	models "github.com/Nooksd/go-server/src/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	SigningKeyActive  = "active"
+	SigningKeyRetired = "retired"
+	SigningKeyStaged  = "staged"
+)
+
+var ErrUnknownSigningKey = errors.New("chave de assinatura desconhecida")
+var ErrMissingKeyEncryptionKey = errors.New("SIGNING_KEY_ENCRYPTION_KEY não configurada")
+var ErrSigningKeyRotationConflict = errors.New("outra rotação de chave de assinatura está em andamento")
+
+var SigningAlgorithm = envString("JWT_ALGORITHM", AlgorithmRS256)
+var AcceptLegacyTokens = envBool("JWT_ACCEPT_LEGACY_HS256", false)
+
+var keyEncryptionKey = os.Getenv("SIGNING_KEY_ENCRYPTION_KEY")
+
+var signingKeyCollection = database.OpenCollection(database.Client, "signingKeys")
+
+var signingKeyCacheDuration = time.Minute
+var signingKeyReloadInterval = time.Second * 5
+var signingKeyStageDuration = time.Hour
+
+type parsedSigningKey struct {
+	key     models.SigningKey
+	method  jwt.SigningMethod
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+var keyring = struct {
+	sync.RWMutex
+	active   *parsedSigningKey
+	keys     map[string]*parsedSigningKey
+	loadedAt time.Time
+}{keys: map[string]*parsedSigningKey{}}
+
+func init() {
+	if keyEncryptionKey == "" {
+		log.Fatal("SIGNING_KEY_ENCRYPTION_KEY não definido no .env")
+	}
+	if keyEncryptionKey == SECRET_KEY {
+		log.Fatal("SIGNING_KEY_ENCRYPTION_KEY deve ser diferente de SECRET_KEY")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := signingKeyCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.M{"kid": 1}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.M{"status": 1}, Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"status": SigningKeyActive})},
+		{Keys: bson.M{"expiresAt": 1}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		log.Printf("Erro ao criar índices de chaves de assinatura: %v\n", err)
+	}
+}
+
+func envString(name string, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func signingMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	case AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	}
+	return nil, fmt.Errorf("algoritmo de assinatura não suportado: %s", algorithm)
+}
+
+func signingKeyCipher() (cipher.AEAD, error) {
+	if keyEncryptionKey == "" {
+		return nil, ErrMissingKeyEncryptionKey
+	}
+
+	key := sha256.Sum256([]byte(keyEncryptionKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encryptPrivateKey(privatePEM string, kid string) (string, error) {
+	aead, err := signingKeyCipher()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(privatePEM), []byte(kid))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptPrivateKey(encrypted string, kid string) (string, error) {
+	aead, err := signingKeyCipher()
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("chave privada criptografada inválida")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, []byte(kid))
+	if err != nil {
+		return "", errors.New("não foi possível descriptografar a chave privada, verifique SIGNING_KEY_ENCRYPTION_KEY")
+	}
+	return string(plain), nil
+}
+
+func GenerateSigningKey(algorithm string) (models.SigningKey, error) {
+	var privateKey crypto.PrivateKey
+	var publicKey crypto.PublicKey
+
+	switch algorithm {
+	case AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return models.SigningKey{}, err
+		}
+		privateKey, publicKey = key, &key.PublicKey
+	case AlgorithmEdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return models.SigningKey{}, err
+		}
+		privateKey, publicKey = private, public
+	default:
+		return models.SigningKey{}, fmt.Errorf("algoritmo de assinatura não suportado: %s", algorithm)
+	}
+
+	privateBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return models.SigningKey{}, err
+	}
+
+	publicBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return models.SigningKey{}, err
+	}
+
+	id := primitive.NewObjectID()
+
+	encrypted, err := encryptPrivateKey(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateBytes})), id.Hex())
+	if err != nil {
+		return models.SigningKey{}, err
+	}
+
+	return models.SigningKey{
+		ID:                  id,
+		Kid:                 id.Hex(),
+		Algorithm:           algorithm,
+		EncryptedPrivateKey: encrypted,
+		PublicKey:           string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicBytes})),
+		Status:              SigningKeyActive,
+		CreatedAt:           time.Now(),
+	}, nil
+}
+
+func signingKeyPrivatePEM(key models.SigningKey) (string, error) {
+	if key.EncryptedPrivateKey != "" {
+		return decryptPrivateKey(key.EncryptedPrivateKey, key.Kid)
+	}
+	return key.PrivateKey, nil
+}
+
+func encryptLegacySigningKey(ctx context.Context, key models.SigningKey) {
+	encrypted, err := encryptPrivateKey(key.PrivateKey, key.Kid)
+	if err != nil {
+		log.Printf("Erro ao criptografar chave de assinatura %s: %v\n", key.Kid, err)
+		return
+	}
+
+	_, err = signingKeyCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": key.ID},
+		bson.M{"$set": bson.M{"encryptedPrivateKey": encrypted}, "$unset": bson.M{"privateKey": ""}},
+	)
+	if err != nil {
+		log.Printf("Erro ao criptografar chave de assinatura %s: %v\n", key.Kid, err)
+	}
+}
+
+func parseSigningKey(key models.SigningKey) (*parsedSigningKey, error) {
+	method, err := signingMethod(key.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &parsedSigningKey{key: key, method: method}
+
+	privatePEM := ""
+	if key.Status == SigningKeyActive {
+		if privatePEM, err = signingKeyPrivatePEM(key); err != nil {
+			return nil, err
+		}
+	}
+
+	switch key.Algorithm {
+	case AlgorithmRS256:
+		if parsed.public, err = jwt.ParseRSAPublicKeyFromPEM([]byte(key.PublicKey)); err != nil {
+			return nil, err
+		}
+		if key.Status == SigningKeyActive {
+			parsed.private, err = jwt.ParseRSAPrivateKeyFromPEM([]byte(privatePEM))
+		}
+	case AlgorithmEdDSA:
+		if parsed.public, err = jwt.ParseEdPublicKeyFromPEM([]byte(key.PublicKey)); err != nil {
+			return nil, err
+		}
+		if key.Status == SigningKeyActive {
+			parsed.private, err = jwt.ParseEdPrivateKeyFromPEM([]byte(privatePEM))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+func loadSigningKeys(ctx context.Context) error {
+	filter := bson.M{"$or": []bson.M{
+		{"status": SigningKeyActive},
+		{"status": SigningKeyRetired, "expiresAt": bson.M{"$gt": time.Now()}},
+	}}
+
+	cursor, err := signingKeyCollection.Find(ctx, filter, options.Find().SetSort(bson.M{"createdAt": -1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []models.SigningKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return err
+	}
+
+	parsedKeys := map[string]*parsedSigningKey{}
+	var active *parsedSigningKey
+
+	for _, key := range keys {
+		if key.PrivateKey != "" {
+			encryptLegacySigningKey(ctx, key)
+		}
+
+		parsed, err := parseSigningKey(key)
+		if err != nil {
+			log.Printf("Erro ao carregar chave de assinatura %s: %v\n", key.Kid, err)
+			continue
+		}
+		parsedKeys[key.Kid] = parsed
+		if active == nil && key.Status == SigningKeyActive {
+			active = parsed
+		}
+	}
+
+	keyring.Lock()
+	keyring.keys = parsedKeys
+	keyring.active = active
+	keyring.loadedAt = time.Now()
+	keyring.Unlock()
+
+	return nil
+}
+
+func refreshKeyring() {
+	keyring.RLock()
+	fresh := time.Since(keyring.loadedAt) < signingKeyCacheDuration
+	keyring.RUnlock()
+
+	if fresh {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := loadSigningKeys(ctx); err != nil {
+		log.Printf("Erro ao carregar chaves de assinatura: %v\n", err)
+	}
+}
+
+func InvalidateSigningKeys() {
+	keyring.Lock()
+	keyring.loadedAt = time.Time{}
+	keyring.Unlock()
+}
+
+func discardStagedSigningKey(ctx context.Context, key models.SigningKey) {
+	if _, err := signingKeyCollection.DeleteOne(ctx, bson.M{"kid": key.Kid, "status": SigningKeyStaged}); err != nil {
+		log.Printf("Erro ao descartar chave de assinatura %s: %v\n", key.Kid, err)
+	}
+}
+
+// A chave nova é gravada como "staged" e só vira ativa depois que a chave
+// ativa lida aqui é aposentada por um update condicional; o índice único
+// parcial em status garante que duas instâncias não terminem com duas ativas.
+func RotateSigningKey(ctx context.Context, algorithm string) (models.SigningKey, error) {
+	var current models.SigningKey
+	err := signingKeyCollection.FindOne(ctx, bson.M{"status": SigningKeyActive}).Decode(&current)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return models.SigningKey{}, err
+	}
+
+	key, err := GenerateSigningKey(algorithm)
+	if err != nil {
+		return models.SigningKey{}, err
+	}
+
+	stagedUntil := time.Now().Add(signingKeyStageDuration)
+	key.Status = SigningKeyStaged
+	key.ExpiresAt = &stagedUntil
+
+	if _, err := signingKeyCollection.InsertOne(ctx, key); err != nil {
+		return models.SigningKey{}, err
+	}
+
+	if current.Kid != "" {
+		now := time.Now()
+		result, err := signingKeyCollection.UpdateOne(
+			ctx,
+			bson.M{"kid": current.Kid, "status": SigningKeyActive},
+			bson.M{
+				"$set":   bson.M{"status": SigningKeyRetired, "retiredAt": now, "expiresAt": now.Add(RefreshTokenDuration)},
+				"$unset": bson.M{"privateKey": "", "encryptedPrivateKey": ""},
+			},
+		)
+		if err == nil && result.MatchedCount == 0 {
+			err = ErrSigningKeyRotationConflict
+		}
+		if err != nil {
+			discardStagedSigningKey(ctx, key)
+			return models.SigningKey{}, err
+		}
+	}
+
+	_, err = signingKeyCollection.UpdateOne(
+		ctx,
+		bson.M{"kid": key.Kid, "status": SigningKeyStaged},
+		bson.M{"$set": bson.M{"status": SigningKeyActive}, "$unset": bson.M{"expiresAt": ""}},
+	)
+	if err != nil {
+		discardStagedSigningKey(ctx, key)
+		if mongo.IsDuplicateKeyError(err) {
+			err = ErrSigningKeyRotationConflict
+		}
+		return models.SigningKey{}, err
+	}
+
+	InvalidateSigningKeys()
+
+	key.Status = SigningKeyActive
+	key.ExpiresAt = nil
+	return key, nil
+}
+
+func GetSigningKeys(ctx context.Context) ([]models.SigningKey, error) {
+	cursor, err := signingKeyCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"createdAt": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []models.SigningKey{}
+	err = cursor.All(ctx, &keys)
+	return keys, err
+}
+
+var activeKeyMutex sync.Mutex
+
+func activeSigningKey() (*parsedSigningKey, error) {
+	refreshKeyring()
+
+	keyring.RLock()
+	active := keyring.active
+	keyring.RUnlock()
+
+	if active != nil {
+		return active, nil
+	}
+
+	activeKeyMutex.Lock()
+	defer activeKeyMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := loadSigningKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	keyring.RLock()
+	active = keyring.active
+	keyring.RUnlock()
+
+	if active != nil {
+		return active, nil
+	}
+
+	log.Printf("Nenhuma chave de assinatura ativa, gerando chave %s\n", SigningAlgorithm)
+	if _, err := RotateSigningKey(ctx, SigningAlgorithm); err != nil && err != ErrSigningKeyRotationConflict {
+		return nil, err
+	}
+	if err := loadSigningKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	keyring.RLock()
+	active = keyring.active
+	keyring.RUnlock()
+
+	if active == nil {
+		return nil, ErrUnknownSigningKey
+	}
+	return active, nil
+}
+
+func SignToken(claims jwt.Claims) (string, error) {
+	key, err := activeSigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.key.Kid
+	return token.SignedString(key.private)
+}
+
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok && AcceptLegacyTokens && SECRET_KEY != "" {
+			return []byte(SECRET_KEY), nil
+		}
+		return nil, ErrUnknownSigningKey
+	}
+
+	refreshKeyring()
+
+	keyring.RLock()
+	key, ok := keyring.keys[kid]
+	loadedAt := keyring.loadedAt
+	keyring.RUnlock()
+
+	if !ok && time.Since(loadedAt) > signingKeyReloadInterval {
+		InvalidateSigningKeys()
+		refreshKeyring()
+
+		keyring.RLock()
+		key, ok = keyring.keys[kid]
+		keyring.RUnlock()
+	}
+
+	if !ok || token.Method.Alg() != key.method.Alg() {
+		return nil, ErrUnknownSigningKey
+	}
+
+	return key.public, nil
+}
+
+func ParseToken(signedToken string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(
+		signedToken,
+		claims,
+		verificationKey,
+		jwt.WithValidMethods([]string{AlgorithmRS256, AlgorithmEdDSA, jwt.SigningMethodHS256.Alg()}),
+	)
+}
+
+func base64Big(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func JWKS() gin.H {
+	refreshKeyring()
+
+	keyring.RLock()
+	defer keyring.RUnlock()
+
+	keys := []gin.H{}
+	for _, key := range keyring.keys {
+		jwk := gin.H{"kid": key.key.Kid, "alg": key.method.Alg(), "use": "sig"}
+
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk["kty"] = "RSA"
+			jwk["n"] = base64Big(public.N)
+			jwk["e"] = base64Big(big.NewInt(int64(public.E)))
+		case ed25519.PublicKey:
+			jwk["kty"] = "OKP"
+			jwk["crv"] = "Ed25519"
+			jwk["x"] = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+
+		keys = append(keys, jwk)
+	}
+
+	return gin.H{"keys": keys}
+}
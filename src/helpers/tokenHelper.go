@@ -1,6 +1,9 @@
 package helpers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"log"
 	"os"
 	"time"
@@ -15,15 +18,29 @@ type SignedDetails struct {
 	Role              string
 	Uid               string
 	UserType          string
+	SessionId         string
+	TokenType         string
+	ImpersonatorUid   string `json:",omitempty"`
 	jwt.RegisteredClaims
 }
 
+const AccessTokenType = "access"
+const RefreshTokenType = "refresh"
+
 var SECRET_KEY string = os.Getenv("SECRET_KEY")
 
-func GenerateTokens(email string, name string, ProfilePictureUrl string, role string, uid string, userType string, keepLogged bool) (signedAccessToken string, signedRefreshToken string, err error) {
-	accessTokenDuration := time.Hour * 24
-	refreshTokenDuration := time.Hour * 24 * 7
+var AccessTokenDuration = time.Hour * 24
+var RefreshTokenDuration = time.Hour * 24 * 7
+var MfaTokenDuration = time.Minute * 5
+var ImpersonationTokenDuration = time.Minute * time.Duration(envInt("IMPERSONATION_TOKEN_MINUTES", 15))
 
+type MfaDetails struct {
+	Uid     string
+	Purpose string
+	jwt.RegisteredClaims
+}
+
+func GenerateTokens(email string, name string, ProfilePictureUrl string, role string, uid string, userType string, sessionId string, tokenId string, keepLogged bool) (signedAccessToken string, signedRefreshToken string, err error) {
 	accessClaims := &SignedDetails{
 		Email:             email,
 		Name:              name,
@@ -31,8 +48,10 @@ func GenerateTokens(email string, name string, ProfilePictureUrl string, role st
 		Role:              role,
 		Uid:               uid,
 		UserType:          userType,
+		SessionId:         sessionId,
+		TokenType:         AccessTokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(accessTokenDuration)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
@@ -44,19 +63,22 @@ func GenerateTokens(email string, name string, ProfilePictureUrl string, role st
 		Role:              role,
 		Uid:               uid,
 		UserType:          userType,
+		SessionId:         sessionId,
+		TokenType:         RefreshTokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(refreshTokenDuration)),
+			ID:        tokenId,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(RefreshTokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims).SignedString([]byte(SECRET_KEY))
+	accessToken, err := SignToken(accessClaims)
 	if err != nil {
 		log.Println("Erro ao criar Access Token:", err)
 		return "", "", err
 	}
 
-	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims).SignedString([]byte(SECRET_KEY))
+	refreshToken, err := SignToken(refreshClaims)
 	if err != nil {
 		log.Println("Erro ao criar Refresh Token:", err)
 		return "", "", err
@@ -68,3 +90,69 @@ func GenerateTokens(email string, name string, ProfilePictureUrl string, role st
 
 	return accessToken, "", nil
 }
+
+func GenerateImpersonationToken(email string, name string, profilePictureUrl string, role string, uid string, userType string, sessionId string, impersonatorUid string, expiresAt time.Time) (string, error) {
+	claims := &SignedDetails{
+		Email:             email,
+		Name:              name,
+		ProfilePictureUrl: profilePictureUrl,
+		Role:              role,
+		Uid:               uid,
+		UserType:          userType,
+		SessionId:         sessionId,
+		TokenType:         AccessTokenType,
+		ImpersonatorUid:   impersonatorUid,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token, err := SignToken(claims)
+	if err != nil {
+		log.Println("Erro ao criar token de personificação:", err)
+		return "", err
+	}
+
+	return token, nil
+}
+
+func IsTokenType(claims jwt.MapClaims, tokenType string) bool {
+	value, _ := claims["TokenType"].(string)
+	return value == tokenType
+}
+
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func GenerateMfaToken(uid string) (string, error) {
+	claims := &MfaDetails{
+		Uid:     uid,
+		Purpose: "mfa",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(MfaTokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token, err := SignToken(claims)
+	if err != nil {
+		log.Println("Erro ao criar MFA Token:", err)
+		return "", err
+	}
+
+	return token, nil
+}
+
+func ParseMfaToken(signedToken string) (string, error) {
+	claims := &MfaDetails{}
+
+	token, err := ParseToken(signedToken, claims)
+	if err != nil || !token.Valid || claims.Purpose != "mfa" || claims.Uid == "" {
+		return "", errors.New("token de verificação inválido ou expirado")
+	}
+
+	return claims.Uid, nil
+}
@@ -0,0 +1,58 @@
+package helpers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	database "github.com/Nooksd/go-server/src/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var userCollection = database.OpenCollection(database.Client, "users")
+
+var ActiveUserFilter = bson.M{"active": bson.M{"$ne": false}}
+
+var userStatusCacheDuration = time.Second * 30
+
+type userStatus struct {
+	active    bool
+	checkedAt time.Time
+}
+
+var userStatusCache = struct {
+	sync.RWMutex
+	users map[string]userStatus
+}{users: map[string]userStatus{}}
+
+func IsUserActive(uid string) bool {
+	userStatusCache.RLock()
+	status, ok := userStatusCache.users[uid]
+	userStatusCache.RUnlock()
+
+	if ok && time.Since(status.checkedAt) < userStatusCacheDuration {
+		return status.active
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"uid": uid, "active": bson.M{"$ne": false}}
+	count, err := userCollection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return ok && status.active
+	}
+
+	userStatusCache.Lock()
+	userStatusCache.users[uid] = userStatus{active: count > 0, checkedAt: time.Now()}
+	userStatusCache.Unlock()
+
+	return count > 0
+}
+
+func InvalidateUserStatus(uid string) {
+	userStatusCache.Lock()
+	delete(userStatusCache.users, uid)
+	userStatusCache.Unlock()
+}
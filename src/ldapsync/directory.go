@@ -0,0 +1,143 @@
+package ldapsync
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	models "github.com/Nooksd/go-server/src/models"
+	"github.com/go-ldap/ldap/v3"
+)
+
+type Attributes struct {
+	Email   string
+	Name    string
+	Role    string
+	Phone   string
+	Manager string
+}
+
+type Config struct {
+	URL                string
+	BindDN             string
+	BindPassword       string
+	BaseDN             string
+	UserFilter         string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	PageSize           int
+	Attributes         Attributes
+	DefaultUserType    string
+	Deactivate         bool
+	Interval           time.Duration
+}
+
+var ErrDisabled = errors.New("sincronização LDAP não configurada")
+
+type Directory interface {
+	Entries(ctx context.Context) ([]models.LDAPEntry, error)
+}
+
+type ldapServer struct {
+	config Config
+}
+
+type StaticDirectory []models.LDAPEntry
+
+func (config Config) Enabled() bool {
+	return config.URL != "" && config.BaseDN != ""
+}
+
+func NewDirectory(config Config) (Directory, error) {
+	if !config.Enabled() {
+		return nil, ErrDisabled
+	}
+	return ldapServer{config: config}, nil
+}
+
+func NormalizeDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(dn))
+	}
+	return strings.ToLower(parsed.String())
+}
+
+func (s ldapServer) Entries(ctx context.Context) ([]models.LDAPEntry, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: s.config.InsecureSkipVerify}
+
+	conn, err := ldap.DialURL(s.config.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetTimeout(time.Until(deadline))
+	}
+
+	if s.config.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.config.BindDN != "" {
+		if err := conn.Bind(s.config.BindDN, s.config.BindPassword); err != nil {
+			return nil, err
+		}
+	}
+
+	attributes := s.config.Attributes
+	request := ldap.NewSearchRequest(
+		s.config.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		s.config.UserFilter,
+		[]string{attributes.Email, attributes.Name, attributes.Role, attributes.Phone, attributes.Manager},
+		nil,
+	)
+
+	result, err := conn.SearchWithPaging(request, uint32(s.config.PageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.LDAPEntry, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		entries = append(entries, models.LDAPEntry{
+			DN:        entry.DN,
+			Email:     entry.GetAttributeValue(attributes.Email),
+			Name:      entry.GetAttributeValue(attributes.Name),
+			Role:      entry.GetAttributeValue(attributes.Role),
+			Phone:     entry.GetAttributeValue(attributes.Phone),
+			ManagerDN: entry.GetAttributeValue(attributes.Manager),
+		})
+	}
+
+	return entries, nil
+}
+
+func LoadStaticDirectory(path string) (StaticDirectory, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries StaticDirectory
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (d StaticDirectory) Entries(ctx context.Context) ([]models.LDAPEntry, error) {
+	return d, nil
+}
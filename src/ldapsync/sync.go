@@ -0,0 +1,345 @@
+package ldapsync
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	models "github.com/Nooksd/go-server/src/models"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const Actor = "ldap-sync"
+
+var validate = validator.New()
+
+type Store interface {
+	RoleExists(name string) bool
+	Users(ctx context.Context, emails []string) ([]models.User, error)
+	CreateUser(ctx context.Context, user models.User) (models.User, error)
+	UpdateUser(ctx context.Context, uid string, updates bson.M, reactivate bool) error
+	ManagerCycle(ctx context.Context, uid string, managerId string) (bool, error)
+	SetManager(ctx context.Context, uid string, managerId string) error
+	DeactivateUser(ctx context.Context, uid string) error
+}
+
+type Options struct {
+	Trigger         string
+	ActorId         string
+	DryRun          bool
+	DefaultUserType string
+	Deactivate      bool
+}
+
+type syncItem struct {
+	entry  models.LDAPEntry
+	user   models.User
+	result int
+}
+
+type syncRun struct {
+	store   Store
+	options Options
+}
+
+func setIfChanged(updates bson.M, changed *[]string, field string, current *string, value *string) {
+	if value == nil {
+		return
+	}
+	if current != nil && *current == *value {
+		return
+	}
+	updates[field] = *value
+	*changed = append(*changed, field)
+}
+
+func finish(report *models.LDAPSyncReport) {
+	report.FinishedAt = time.Now()
+	for _, entry := range report.Entries {
+		report.Summary[entry.Action]++
+	}
+}
+
+func (r syncRun) syncUser(ctx context.Context, item *syncItem, result *models.LDAPSyncEntry, byEmail map[string]*models.User) {
+	email := result.Email
+	name := strings.TrimSpace(item.entry.Name)
+	role := strings.TrimSpace(item.entry.Role)
+	phone := strings.TrimSpace(item.entry.Phone)
+
+	if item.user.Uid == "" {
+		result.Action = "create"
+		if r.options.DryRun {
+			return
+		}
+
+		userType := r.options.DefaultUserType
+		user := models.User{Name: &name, Email: &email, UserType: &userType, LdapDN: item.entry.DN}
+		if role != "" {
+			user.Role = &role
+		}
+		if phone != "" {
+			user.PhoneNumber = &phone
+		}
+
+		created, err := r.store.CreateUser(ctx, user)
+		if err != nil {
+			log.Printf("Erro ao criar usuário LDAP %s: %v\n", item.entry.DN, err)
+			result.Action = "error"
+			result.Error = "erro ao salvar usuário"
+			return
+		}
+
+		item.user = created
+		result.Uid = created.Uid
+		return
+	}
+
+	result.Uid = item.user.Uid
+
+	updates := bson.M{}
+	changed := []string{}
+	setIfChanged(updates, &changed, "name", item.user.Name, &name)
+	if role != "" {
+		setIfChanged(updates, &changed, "role", item.user.Role, &role)
+	}
+	if phone != "" {
+		setIfChanged(updates, &changed, "phoneNumber", item.user.PhoneNumber, &phone)
+	}
+	setIfChanged(updates, &changed, "ldapDn", &item.user.LdapDN, &item.entry.DN)
+
+	if item.user.Email == nil || !strings.EqualFold(*item.user.Email, email) {
+		if other, ok := byEmail[email]; ok && other.Uid != item.user.Uid {
+			result.Action = "error"
+			result.Error = "email já pertence a outro usuário"
+			return
+		}
+		updates["email"] = email
+		changed = append(changed, "email")
+	}
+
+	result.Action = "update"
+	if !item.user.IsActive() {
+		if item.user.DeactivatedBy != Actor {
+			result.Action = "skip"
+			result.Error = "usuário desativado manualmente"
+			return
+		}
+		result.Action = "reactivate"
+	}
+
+	if len(changed) == 0 && result.Action == "update" {
+		result.Action = "unchanged"
+		return
+	}
+	result.Changes = changed
+
+	if r.options.DryRun {
+		return
+	}
+
+	if err := r.store.UpdateUser(ctx, item.user.Uid, updates, result.Action == "reactivate"); err != nil {
+		log.Printf("Erro ao atualizar usuário LDAP %s: %v\n", item.entry.DN, err)
+		result.Action = "error"
+		result.Error = "erro ao salvar usuário"
+	}
+}
+
+func (r syncRun) syncManager(ctx context.Context, item *syncItem, result *models.LDAPSyncEntry, dnToUid map[string]string) {
+	managerId := ""
+	if item.entry.ManagerDN != "" {
+		uid, ok := dnToUid[NormalizeDN(item.entry.ManagerDN)]
+		if !ok {
+			result.Error = "gestor não encontrado no diretório: " + item.entry.ManagerDN
+			return
+		}
+		managerId = uid
+	}
+
+	if managerId == item.user.ManagerId {
+		return
+	}
+
+	if result.Action == "unchanged" {
+		result.Action = "update"
+	}
+	result.Changes = append(result.Changes, "managerId")
+
+	if r.options.DryRun || item.user.Uid == "" {
+		return
+	}
+
+	if managerId != "" {
+		cycle, err := r.store.ManagerCycle(ctx, item.user.Uid, managerId)
+		if err != nil || cycle {
+			result.Error = "gestor inválido ou cria hierarquia circular"
+			result.Changes = result.Changes[:len(result.Changes)-1]
+			return
+		}
+	}
+
+	if err := r.store.SetManager(ctx, item.user.Uid, managerId); err != nil {
+		log.Printf("Erro ao atualizar gestor de %s: %v\n", item.user.Uid, err)
+		result.Action = "error"
+		result.Error = "erro ao atualizar gestor"
+	}
+}
+
+func (r syncRun) deactivateMissing(ctx context.Context, report *models.LDAPSyncReport, users []models.User, seen map[string]bool) {
+	for _, user := range users {
+		if user.LdapDN == "" || !user.IsActive() || seen[user.Uid] {
+			continue
+		}
+
+		result := models.LDAPSyncEntry{DN: user.LdapDN, Uid: user.Uid, Action: "deactivate"}
+		if user.Email != nil {
+			result.Email = *user.Email
+		}
+
+		if !r.options.DryRun {
+			if err := r.store.DeactivateUser(ctx, user.Uid); err != nil {
+				log.Printf("Erro ao desativar usuário LDAP %s: %v\n", user.Uid, err)
+				result.Action = "error"
+				result.Error = "erro ao desativar usuário"
+			}
+		}
+
+		report.Entries = append(report.Entries, result)
+	}
+}
+
+// Run compara o diretório com os usuários do store e aplica as diferenças,
+// devolvendo o relatório e os usuários criados nesta execução.
+func Run(ctx context.Context, directory Directory, store Store, options Options) (report models.LDAPSyncReport, created []models.User) {
+	r := syncRun{store: store, options: options}
+	report = models.LDAPSyncReport{
+		ID:        primitive.NewObjectID(),
+		Trigger:   options.Trigger,
+		ActorId:   options.ActorId,
+		DryRun:    options.DryRun,
+		Summary:   map[string]int{},
+		Entries:   []models.LDAPSyncEntry{},
+		StartedAt: time.Now(),
+	}
+	defer finish(&report)
+
+	if !store.RoleExists(options.DefaultUserType) {
+		report.Error = "tipo de usuário padrão inválido: " + options.DefaultUserType
+		return report, nil
+	}
+
+	entries, err := directory.Entries(ctx)
+	if err != nil {
+		log.Printf("Erro ao consultar diretório LDAP: %v\n", err)
+		report.Error = "erro ao consultar diretório LDAP: " + err.Error()
+		return report, nil
+	}
+
+	emails := []string{}
+	directoryDNs := map[string]bool{}
+	for i := range entries {
+		entries[i].Email = strings.ToLower(strings.TrimSpace(entries[i].Email))
+		emails = append(emails, entries[i].Email)
+		directoryDNs[NormalizeDN(entries[i].DN)] = true
+	}
+
+	users, err := store.Users(ctx, emails)
+	if err != nil {
+		log.Printf("Erro ao buscar usuários para sincronização LDAP: %v\n", err)
+		report.Error = "erro ao buscar usuários"
+		return report, nil
+	}
+
+	byDN := map[string]*models.User{}
+	byEmail := map[string]*models.User{}
+	for i := range users {
+		if users[i].LdapDN != "" {
+			byDN[NormalizeDN(users[i].LdapDN)] = &users[i]
+		}
+		if users[i].Email != nil {
+			byEmail[strings.ToLower(*users[i].Email)] = &users[i]
+		}
+	}
+
+	items := []*syncItem{}
+	seenDN := map[string]bool{}
+	seenEmail := map[string]string{}
+	seenUid := map[string]bool{}
+	dnToUid := map[string]string{}
+
+	for _, entry := range entries {
+		dn := NormalizeDN(entry.DN)
+		result := models.LDAPSyncEntry{DN: entry.DN, Email: entry.Email}
+
+		switch {
+		case seenDN[dn]:
+			result.Error = "entrada duplicada no diretório"
+		case entry.Email == "" || validate.Var(entry.Email, "email") != nil:
+			result.Error = "email ausente ou inválido"
+		case strings.TrimSpace(entry.Name) == "":
+			result.Error = "nome ausente"
+		case seenEmail[entry.Email] != "":
+			result.Error = "email duplicado no diretório: " + seenEmail[entry.Email]
+		}
+		seenDN[dn] = true
+
+		if result.Error != "" {
+			if user, ok := byDN[dn]; ok {
+				seenUid[user.Uid] = true
+			}
+			result.Action = "error"
+			report.Entries = append(report.Entries, result)
+			continue
+		}
+		seenEmail[entry.Email] = entry.DN
+
+		item := &syncItem{entry: entry, result: len(report.Entries)}
+		if user, ok := byDN[dn]; ok {
+			item.user = *user
+		} else if user, ok := byEmail[entry.Email]; ok && (user.LdapDN == "" || !directoryDNs[NormalizeDN(user.LdapDN)]) {
+			item.user = *user
+		}
+		if item.user.Uid != "" && seenUid[item.user.Uid] {
+			result.Action = "error"
+			result.Error = "usuário já sincronizado por outra entrada"
+			report.Entries = append(report.Entries, result)
+			continue
+		}
+
+		r.syncUser(ctx, item, &result, byEmail)
+		report.Entries = append(report.Entries, result)
+
+		if result.Action == "error" {
+			if item.user.Uid != "" {
+				seenUid[item.user.Uid] = true
+			}
+			continue
+		}
+		if result.Action == "create" && !options.DryRun {
+			created = append(created, item.user)
+		}
+
+		if item.user.Uid != "" {
+			seenUid[item.user.Uid] = true
+			dnToUid[dn] = item.user.Uid
+		} else {
+			dnToUid[dn] = "pendente:" + entry.DN
+		}
+		items = append(items, item)
+	}
+
+	for _, item := range items {
+		if report.Entries[item.result].Action == "skip" {
+			continue
+		}
+		r.syncManager(ctx, item, &report.Entries[item.result], dnToUid)
+	}
+
+	if options.Deactivate && len(items) > 0 {
+		r.deactivateMissing(ctx, &report, users, seenUid)
+	}
+
+	return report, created
+}
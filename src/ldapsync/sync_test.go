@@ -0,0 +1,333 @@
+package ldapsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	models "github.com/Nooksd/go-server/src/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type memoryStore struct {
+	users   []*models.User
+	cycles  map[string]string
+	failing map[string]bool
+	writes  int
+}
+
+func newMemoryStore(users ...models.User) *memoryStore {
+	store := &memoryStore{cycles: map[string]string{}, failing: map[string]bool{}}
+	for _, user := range users {
+		store.users = append(store.users, &user)
+	}
+	return store
+}
+
+func (s *memoryStore) user(uid string) *models.User {
+	for _, user := range s.users {
+		if user.Uid == uid {
+			return user
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) RoleExists(name string) bool {
+	return name == "USER"
+}
+
+func (s *memoryStore) Users(ctx context.Context, emails []string) ([]models.User, error) {
+	users := []models.User{}
+	for _, user := range s.users {
+		users = append(users, *user)
+	}
+	return users, nil
+}
+
+func (s *memoryStore) CreateUser(ctx context.Context, user models.User) (models.User, error) {
+	if s.failing[*user.Email] {
+		return models.User{}, errors.New("falha simulada")
+	}
+	s.writes++
+	user.Uid = fmt.Sprintf("uid-%d", len(s.users)+1)
+	user.Pending = true
+	s.users = append(s.users, &user)
+	return user, nil
+}
+
+func (s *memoryStore) UpdateUser(ctx context.Context, uid string, updates bson.M, reactivate bool) error {
+	s.writes++
+	user := s.user(uid)
+	for field, value := range updates {
+		text := value.(string)
+		switch field {
+		case "name":
+			user.Name = &text
+		case "email":
+			user.Email = &text
+		case "role":
+			user.Role = &text
+		case "phoneNumber":
+			user.PhoneNumber = &text
+		case "ldapDn":
+			user.LdapDN = text
+		}
+	}
+	if reactivate {
+		user.Active = nil
+		user.DeactivatedBy = ""
+	}
+	return nil
+}
+
+func (s *memoryStore) ManagerCycle(ctx context.Context, uid string, managerId string) (bool, error) {
+	return s.cycles[uid] == managerId, nil
+}
+
+func (s *memoryStore) SetManager(ctx context.Context, uid string, managerId string) error {
+	s.writes++
+	s.user(uid).ManagerId = managerId
+	return nil
+}
+
+func (s *memoryStore) DeactivateUser(ctx context.Context, uid string) error {
+	s.writes++
+	inactive := false
+	user := s.user(uid)
+	user.Active = &inactive
+	user.DeactivatedBy = Actor
+	return nil
+}
+
+func text(value string) *string {
+	return &value
+}
+
+func syncOptions(dryRun bool) Options {
+	return Options{Trigger: "test", DryRun: dryRun, DefaultUserType: "USER", Deactivate: true}
+}
+
+func entryFor(t *testing.T, report models.LDAPSyncReport, dn string) models.LDAPSyncEntry {
+	t.Helper()
+	for _, entry := range report.Entries {
+		if entry.DN == dn {
+			return entry
+		}
+	}
+	t.Fatalf("entrada %s ausente do relatório: %+v", dn, report.Entries)
+	return models.LDAPSyncEntry{}
+}
+
+func TestRunCreatesUsersAndLinksManagers(t *testing.T) {
+	directory := StaticDirectory{
+		{DN: "uid=ana,ou=people,dc=example,dc=com", Email: "Ana@Example.com", Name: " Ana ", Role: "Diretora"},
+		{DN: "uid=bruno,ou=people,dc=example,dc=com", Email: "bruno@example.com", Name: "Bruno", Phone: "1199999", ManagerDN: "UID=ana, OU=people, DC=example, DC=com"},
+	}
+	store := newMemoryStore()
+
+	report, created := Run(context.Background(), directory, store, syncOptions(false))
+
+	if report.Error != "" {
+		t.Fatal(report.Error)
+	}
+	if len(created) != 2 || report.Summary["create"] != 2 {
+		t.Fatalf("esperado 2 criações, obtido %v (%d criados)", report.Summary, len(created))
+	}
+	if report.FinishedAt.IsZero() {
+		t.Error("relatório sem horário de término")
+	}
+
+	ana := store.user(created[0].Uid)
+	if *ana.Email != "ana@example.com" || *ana.Name != "Ana" || *ana.Role != "Diretora" || *ana.UserType != "USER" {
+		t.Errorf("usuária criada com dados inesperados: %+v", ana)
+	}
+
+	bruno := store.user(created[1].Uid)
+	if bruno.ManagerId != ana.Uid {
+		t.Errorf("gestor de Bruno = %q, esperado %q", bruno.ManagerId, ana.Uid)
+	}
+	if changes := entryFor(t, report, directory[1].DN).Changes; len(changes) != 1 || changes[0] != "managerId" {
+		t.Errorf("alterações inesperadas para Bruno: %v", changes)
+	}
+}
+
+func TestRunDryRunDoesNotWrite(t *testing.T) {
+	directory := StaticDirectory{
+		{DN: "uid=ana,dc=example,dc=com", Email: "ana@example.com", Name: "Ana"},
+		{DN: "uid=bruno,dc=example,dc=com", Email: "bruno@example.com", Name: "Bruno Silva"},
+	}
+	store := newMemoryStore(
+		models.User{Uid: "u1", Name: text("Bruno"), Email: text("bruno@example.com"), LdapDN: "uid=bruno,dc=example,dc=com"},
+		models.User{Uid: "u2", Name: text("Carla"), Email: text("carla@example.com"), LdapDN: "uid=carla,dc=example,dc=com"},
+	)
+
+	report, created := Run(context.Background(), directory, store, syncOptions(true))
+
+	if store.writes != 0 || len(created) != 0 {
+		t.Fatalf("simulação escreveu no store: %d escritas, %d criados", store.writes, len(created))
+	}
+	if report.Summary["create"] != 1 || report.Summary["update"] != 1 || report.Summary["deactivate"] != 1 {
+		t.Fatalf("resumo inesperado: %v", report.Summary)
+	}
+}
+
+func TestRunUpdatesAndMatchesByEmail(t *testing.T) {
+	directory := StaticDirectory{
+		{DN: "uid=ana,dc=example,dc=com", Email: "ana.nova@example.com", Name: "Ana"},
+		{DN: "uid=bruno,dc=example,dc=com", Email: "bruno@example.com", Name: "Bruno"},
+		{DN: "uid=carla,dc=example,dc=com", Email: "carla@example.com", Name: "Carla"},
+	}
+	store := newMemoryStore(
+		models.User{Uid: "u1", Name: text("Ana"), Email: text("ana@example.com"), LdapDN: "uid=ana,dc=example,dc=com"},
+		models.User{Uid: "u2", Name: text("Bruno"), Email: text("Bruno@Example.com")},
+		models.User{Uid: "u3", Name: text("Carla"), Email: text("carla@example.com"), LdapDN: "uid=carla,dc=example,dc=com"},
+	)
+
+	report, _ := Run(context.Background(), directory, store, syncOptions(false))
+
+	if entry := entryFor(t, report, directory[0].DN); entry.Action != "update" || *store.user("u1").Email != "ana.nova@example.com" {
+		t.Errorf("email de Ana não atualizado: %+v", entry)
+	}
+	if entry := entryFor(t, report, directory[1].DN); entry.Action != "update" || entry.Uid != "u2" || store.user("u2").LdapDN != directory[1].DN {
+		t.Errorf("Bruno não vinculado pelo email: %+v", entry)
+	}
+	if entry := entryFor(t, report, directory[2].DN); entry.Action != "unchanged" {
+		t.Errorf("Carla deveria estar inalterada: %+v", entry)
+	}
+}
+
+func TestRunReactivatesOnlyUsersDeactivatedBySync(t *testing.T) {
+	inactive := false
+	directory := StaticDirectory{
+		{DN: "uid=ana,dc=example,dc=com", Email: "ana@example.com", Name: "Ana"},
+		{DN: "uid=bruno,dc=example,dc=com", Email: "bruno@example.com", Name: "Bruno"},
+	}
+	store := newMemoryStore(
+		models.User{Uid: "u1", Name: text("Ana"), Email: text("ana@example.com"), LdapDN: directory[0].DN, Active: &inactive, DeactivatedBy: Actor},
+		models.User{Uid: "u2", Name: text("Bruno"), Email: text("bruno@example.com"), LdapDN: directory[1].DN, Active: &inactive, DeactivatedBy: "admin"},
+	)
+
+	report, _ := Run(context.Background(), directory, store, syncOptions(false))
+
+	if entry := entryFor(t, report, directory[0].DN); entry.Action != "reactivate" || !store.user("u1").IsActive() {
+		t.Errorf("Ana deveria ser reativada: %+v", entry)
+	}
+	if entry := entryFor(t, report, directory[1].DN); entry.Action != "skip" || store.user("u2").IsActive() {
+		t.Errorf("Bruno foi desativado manualmente e deveria ser ignorado: %+v", entry)
+	}
+}
+
+func TestRunDeactivatesUsersMissingFromDirectory(t *testing.T) {
+	directory := StaticDirectory{
+		{DN: "uid=ana,dc=example,dc=com", Email: "ana@example.com", Name: "Ana"},
+	}
+	users := []models.User{
+		{Uid: "u1", Name: text("Ana"), Email: text("ana@example.com"), LdapDN: directory[0].DN},
+		{Uid: "u2", Name: text("Bruno"), Email: text("bruno@example.com"), LdapDN: "uid=bruno,dc=example,dc=com"},
+		{Uid: "u3", Name: text("Carla"), Email: text("carla@example.com")},
+	}
+
+	keep := syncOptions(false)
+	keep.Deactivate = false
+	if report, _ := Run(context.Background(), directory, newMemoryStore(users...), keep); report.Summary["deactivate"] != 0 {
+		t.Fatalf("desativação desligada, obtido %v", report.Summary)
+	}
+
+	store := newMemoryStore(users...)
+	report, _ := Run(context.Background(), directory, store, syncOptions(false))
+
+	if report.Summary["deactivate"] != 1 || store.user("u2").IsActive() || store.user("u2").DeactivatedBy != Actor {
+		t.Fatalf("Bruno deveria ser desativado: %v", report.Summary)
+	}
+	if !store.user("u3").IsActive() {
+		t.Error("usuária sem vínculo LDAP não deveria ser desativada")
+	}
+
+	if report, _ := Run(context.Background(), StaticDirectory{}, store, syncOptions(false)); report.Summary["deactivate"] != 0 {
+		t.Fatalf("diretório vazio não deveria desativar todos: %v", report.Summary)
+	}
+}
+
+func TestRunReportsInvalidEntries(t *testing.T) {
+	directory := StaticDirectory{
+		{DN: "uid=ana,dc=example,dc=com", Email: "ana@example.com", Name: "Ana"},
+		{DN: "uid=ana,dc=example,dc=com", Email: "ana2@example.com", Name: "Ana 2"},
+		{DN: "uid=bruno,dc=example,dc=com", Email: "não-é-email", Name: "Bruno"},
+		{DN: "uid=carla,dc=example,dc=com", Email: "carla@example.com", Name: " "},
+		{DN: "uid=daniel,dc=example,dc=com", Email: "ANA@example.com", Name: "Daniel"},
+		{DN: "uid=eva,dc=example,dc=com", Email: "eva@example.com", Name: "Eva", ManagerDN: "uid=ninguem,dc=example,dc=com"},
+	}
+	store := newMemoryStore(
+		models.User{Uid: "u1", Name: text("Bruno"), Email: text("bruno@example.com"), LdapDN: "uid=bruno,dc=example,dc=com"},
+	)
+
+	report, created := Run(context.Background(), directory, store, syncOptions(false))
+
+	if report.Summary["error"] != 4 || len(created) != 2 {
+		t.Fatalf("resumo inesperado: %v (%d criados)", report.Summary, len(created))
+	}
+	if report.Summary["deactivate"] != 0 || !store.user("u1").IsActive() {
+		t.Error("usuário com entrada inválida não deveria ser desativado")
+	}
+	if entry := entryFor(t, report, directory[5].DN); entry.Action != "create" || entry.Error == "" {
+		t.Errorf("gestor ausente deveria ser reportado: %+v", entry)
+	}
+}
+
+func TestRunRejectsManagerCycles(t *testing.T) {
+	directory := StaticDirectory{
+		{DN: "uid=ana,dc=example,dc=com", Email: "ana@example.com", Name: "Ana", ManagerDN: "uid=bruno,dc=example,dc=com"},
+		{DN: "uid=bruno,dc=example,dc=com", Email: "bruno@example.com", Name: "Bruno"},
+	}
+	store := newMemoryStore(
+		models.User{Uid: "u1", Name: text("Ana"), Email: text("ana@example.com"), LdapDN: directory[0].DN},
+		models.User{Uid: "u2", Name: text("Bruno"), Email: text("bruno@example.com"), LdapDN: directory[1].DN},
+	)
+	store.cycles["u1"] = "u2"
+
+	report, _ := Run(context.Background(), directory, store, syncOptions(false))
+
+	entry := entryFor(t, report, directory[0].DN)
+	if store.user("u1").ManagerId != "" || entry.Error == "" || len(entry.Changes) != 0 {
+		t.Fatalf("ciclo de gestores deveria ser rejeitado: %+v", entry)
+	}
+}
+
+func TestRunFailures(t *testing.T) {
+	invalid := syncOptions(false)
+	invalid.DefaultUserType = "INEXISTENTE"
+	if report, _ := Run(context.Background(), StaticDirectory{}, newMemoryStore(), invalid); report.Error == "" {
+		t.Error("tipo de usuário padrão inválido deveria falhar")
+	}
+
+	store := newMemoryStore()
+	store.failing["ana@example.com"] = true
+	directory := StaticDirectory{{DN: "uid=ana,dc=example,dc=com", Email: "ana@example.com", Name: "Ana"}}
+
+	report, created := Run(context.Background(), directory, store, syncOptions(false))
+	if entry := entryFor(t, report, directory[0].DN); entry.Action != "error" || len(created) != 0 {
+		t.Errorf("falha ao criar deveria ser reportada: %+v", entry)
+	}
+}
+
+func TestLoadStaticDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "directory.json")
+	content := `[{"dn": "uid=ana,dc=example,dc=com", "email": "ana@example.com", "name": "Ana", "managerDn": "uid=bruno,dc=example,dc=com"}]`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	directory, err := LoadStaticDirectory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, _ := directory.Entries(context.Background())
+	if len(entries) != 1 || entries[0].ManagerDN != "uid=bruno,dc=example,dc=com" {
+		t.Fatalf("entradas inesperadas: %+v", entries)
+	}
+}
@@ -3,29 +3,59 @@ package middlewares
 import (
 	"log"
 	"net/http"
-	"os"
+	"strings"
 
+	helper "github.com/Nooksd/go-server/src/helpers"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var SECRET_KEY string = os.Getenv("SECRET_KEY")
-
 func Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
+		authHeader := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+		apiKey := c.GetHeader("X-API-Key")
+		if apiKey == "" && strings.HasPrefix(authHeader, "ApiKey ") {
+			apiKey = strings.TrimPrefix(authHeader, "ApiKey ")
+		}
+		if apiKey == "" && helper.IsApiKey(authHeader) {
+			apiKey = authHeader
+		}
+
+		if apiKey != "" {
+			account, key, err := helper.AuthenticateApiKey(apiKey)
+			if err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+				c.Abort()
+				return
+			}
+
+			scope, allowed := apiKeyScope(c)
+			if !allowed {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Chaves de API não podem acessar esta rota"})
+				c.Abort()
+				return
+			}
+
+			claims := helper.ServiceAccountClaims(account, key)
+			if !helper.HasScope(claims, scope) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Chave de API sem permissão", "permission": scope})
+				c.Abort()
+				return
+			}
+
+			c.Set("user", claims)
+			c.Next()
+			return
+		}
+
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token não fornecido"})
 			c.Abort()
 			return
 		}
 
-		token, err := jwt.Parse(authHeader, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrTokenMalformed
-			}
-			return []byte(SECRET_KEY), nil
-		})
+		token, err := helper.ParseToken(authHeader, jwt.MapClaims{})
 
 		if err != nil {
 			log.Println("Erro ao validar o token:", err)
@@ -34,14 +64,51 @@ func Authenticate() gin.HandlerFunc {
 			return
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			c.Set("user", claims)
-		} else {
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok || !token.Valid || !helper.IsTokenType(claims, helper.AccessTokenType) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido"})
 			c.Abort()
 			return
 		}
 
+		sessionId, _ := claims["SessionId"].(string)
+		if sessionId == "" || !helper.IsSessionActive(sessionId) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Sessão encerrada, faça login novamente"})
+			c.Abort()
+			return
+		}
+
+		uid, _ := claims["Uid"].(string)
+		if !helper.IsUserActive(uid) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Usuário desativado"})
+			c.Abort()
+			return
+		}
+
+		c.Set("user", claims)
+
+		if impersonator := helper.Impersonator(claims); impersonator != "" {
+			authenticateImpersonation(c, impersonator)
+			return
+		}
+
 		c.Next()
 	}
 }
+
+func authenticateImpersonation(c *gin.Context, impersonator string) {
+	if !helper.IsUserActive(impersonator) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Usuário desativado"})
+		c.Abort()
+		return
+	}
+
+	if helper.ImpersonationBlocked(c.Request.Method, c.FullPath()) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Ação não permitida durante personificação"})
+		c.Abort()
+	} else {
+		c.Next()
+	}
+
+	helper.AuditRequest(c, helper.AuditImpersonatedRequest)
+}
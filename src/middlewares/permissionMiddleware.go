@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"net/http"
+
+	helper "github.com/Nooksd/go-server/src/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if helper.GetClaim(c, "UserType") == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			c.Abort()
+			return
+		}
+
+		for _, permission := range permissions {
+			if helper.CheckPermission(c, permission) != nil {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Usuário sem permissão", "permission": permission})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+var apiKeyRoutes = map[string]string{}
+
+// AllowAPIKey libera a rota para chaves de API com o escopo informado;
+// rotas não registradas aqui recusam chaves de API.
+func AllowAPIKey(method string, path string, scope string) {
+	apiKeyRoutes[method+" "+path] = scope
+}
+
+func apiKeyScope(c *gin.Context) (string, bool) {
+	scope, ok := apiKeyRoutes[c.Request.Method+" "+c.FullPath()]
+	return scope, ok
+}
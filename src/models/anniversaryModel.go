@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+type AnniversarySettings struct {
+	ID         string    `bson:"_id" json:"-"`
+	Enabled    bool      `bson:"enabled" json:"enabled"`
+	Notify     bool      `bson:"notify" json:"notify"`
+	CreatePost bool      `bson:"createPost" json:"createPost"`
+	Hour       int       `bson:"hour" json:"hour" validate:"gte=0,lte=23"`
+	Message    string    `bson:"message" json:"message" validate:"required,max=500"`
+	AuthorName string    `bson:"authorName" json:"authorName" validate:"required,max=120"`
+	UpdatedBy  string    `bson:"updatedBy,omitempty" json:"updatedBy,omitempty"`
+	UpdatedAt  time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+type Anniversary struct {
+	Uid               string    `json:"uid"`
+	Name              string    `json:"name"`
+	Role              string    `json:"role"`
+	ProfilePictureUrl string    `json:"profilePictureUrl"`
+	EntryDate         time.Time `json:"entryDate"`
+	Date              time.Time `json:"date"`
+	Years             int       `json:"years"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditChange struct {
+	Before interface{} `bson:"before" json:"before"`
+	After  interface{} `bson:"after" json:"after"`
+}
+
+type AuditEntry struct {
+	ID             primitive.ObjectID     `bson:"_id" json:"id"`
+	ActorId        string                 `bson:"actorId" json:"actorId"`
+	ActorName      string                 `bson:"actorName" json:"actorName"`
+	ActorType      string                 `bson:"actorType" json:"actorType"`
+	ImpersonatorId string                 `bson:"impersonatorId,omitempty" json:"impersonatorId,omitempty"`
+	Action         string                 `bson:"action" json:"action"`
+	TargetType     string                 `bson:"targetType" json:"targetType"`
+	TargetId       string                 `bson:"targetId" json:"targetId"`
+	Changes        map[string]AuditChange `bson:"changes,omitempty" json:"changes,omitempty"`
+	Method         string                 `bson:"method,omitempty" json:"method,omitempty"`
+	Path           string                 `bson:"path,omitempty" json:"path,omitempty"`
+	Status         int                    `bson:"status,omitempty" json:"status,omitempty"`
+	IP             string                 `bson:"ip" json:"ip"`
+	UserAgent      string                 `bson:"userAgent" json:"userAgent"`
+	CreatedAt      time.Time              `bson:"createdAt" json:"createdAt"`
+}
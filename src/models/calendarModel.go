@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+type CalendarBirthday struct {
+	Uid               string    `json:"uid"`
+	Name              string    `json:"name"`
+	Role              string    `json:"role"`
+	ProfilePictureUrl string    `json:"profilePictureUrl"`
+	Birthday          time.Time `json:"birthday"`
+	Date              time.Time `json:"date"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Department struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	Name        string             `bson:"name" json:"name" validate:"required,max=120"`
+	Description string             `bson:"description" json:"description"`
+	HeadId      string             `bson:"headId,omitempty" json:"headId,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+type OrgNode struct {
+	Uid               string     `bson:"uid" json:"uid"`
+	Name              string     `bson:"name" json:"name"`
+	Role              string     `bson:"role" json:"role"`
+	ProfilePictureUrl string     `bson:"profilePictureUrl" json:"profilePictureUrl"`
+	DepartmentId      string     `bson:"departmentId" json:"departmentId,omitempty"`
+	ManagerId         string     `bson:"managerId" json:"managerId,omitempty"`
+	Reports           []*OrgNode `bson:"-" json:"reports"`
+}
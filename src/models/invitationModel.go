@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Invitation struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	UserId     string             `bson:"userId" json:"userId"`
+	TokenHash  string             `bson:"tokenHash" json:"-"`
+	InvitedBy  string             `bson:"invitedBy,omitempty" json:"invitedBy,omitempty"`
+	IP         string             `bson:"ip" json:"ip"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+	ExpiresAt  time.Time          `bson:"expiresAt" json:"expiresAt"`
+	AcceptedAt *time.Time         `bson:"acceptedAt,omitempty" json:"acceptedAt,omitempty"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type JobRun struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	Job       string             `bson:"job" json:"job"`
+	Key       string             `bson:"key" json:"key"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LDAPEntry struct {
+	DN        string `json:"dn"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	Phone     string `json:"phone"`
+	ManagerDN string `json:"managerDn"`
+}
+
+type LDAPSyncEntry struct {
+	DN      string   `bson:"dn" json:"dn"`
+	Email   string   `bson:"email" json:"email"`
+	Uid     string   `bson:"uid,omitempty" json:"uid,omitempty"`
+	Action  string   `bson:"action" json:"action"`
+	Changes []string `bson:"changes,omitempty" json:"changes,omitempty"`
+	Error   string   `bson:"error,omitempty" json:"error,omitempty"`
+}
+
+type LDAPSyncReport struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	Trigger    string             `bson:"trigger" json:"trigger"`
+	ActorId    string             `bson:"actorId,omitempty" json:"actorId,omitempty"`
+	DryRun     bool               `bson:"dryRun" json:"dryRun"`
+	Summary    map[string]int     `bson:"summary" json:"summary"`
+	Entries    []LDAPSyncEntry    `bson:"entries" json:"entries"`
+	Error      string             `bson:"error,omitempty" json:"error,omitempty"`
+	StartedAt  time.Time          `bson:"startedAt" json:"startedAt"`
+	FinishedAt time.Time          `bson:"finishedAt" json:"finishedAt"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LoginAttempt struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Key           string             `bson:"key" json:"key"`
+	Failures      int                `bson:"failures" json:"failures"`
+	LastFailureAt time.Time          `bson:"lastFailureAt" json:"lastFailureAt"`
+	LockedUntil   time.Time          `bson:"lockedUntil" json:"lockedUntil"`
+	ExpiresAt     time.Time          `bson:"expiresAt" json:"expiresAt"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OIDCState struct {
+	ID           primitive.ObjectID `bson:"_id" json:"id"`
+	StateHash    string             `bson:"stateHash" json:"-"`
+	CodeVerifier string             `bson:"codeVerifier" json:"-"`
+	Nonce        string             `bson:"nonce" json:"-"`
+	IP           string             `bson:"ip" json:"ip"`
+	CreatedAt    time.Time          `bson:"createdAt" json:"createdAt"`
+	ExpiresAt    time.Time          `bson:"expiresAt" json:"expiresAt"`
+}
+
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
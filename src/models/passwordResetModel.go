@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PasswordReset struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	UserId    string             `bson:"userId" json:"userId"`
+	TokenHash string             `bson:"tokenHash" json:"-"`
+	IP        string             `bson:"ip" json:"ip"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	ExpiresAt time.Time          `bson:"expiresAt" json:"expiresAt"`
+	UsedAt    *time.Time         `bson:"usedAt,omitempty" json:"usedAt,omitempty"`
+}
+
+type PasswordResetRequestCount struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Key           string             `bson:"key" json:"key"`
+	Requests      int                `bson:"requests" json:"requests"`
+	LastRequestAt time.Time          `bson:"lastRequestAt" json:"lastRequestAt"`
+	ExpiresAt     time.Time          `bson:"expiresAt" json:"expiresAt"`
+}
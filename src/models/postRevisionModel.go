@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PostRevision struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	PostId     primitive.ObjectID `bson:"postId" json:"postId"`
+	Version    int                `bson:"version" json:"version"`
+	Text       string             `bson:"text" json:"text"`
+	Hashtags   []string           `bson:"hashtags" json:"hashtags"`
+	ImageUrl   string             `bson:"imageUrl" json:"imageUrl"`
+	WrittenAt  time.Time          `bson:"writtenAt" json:"writtenAt"`
+	ReplacedBy string             `bson:"replacedBy" json:"replacedBy"`
+	ReplacedAt time.Time          `bson:"replacedAt" json:"replacedAt"`
+	Reason     string             `bson:"reason" json:"reason"`
+}
+
+type PostEdit struct {
+	Text     *string   `json:"text"`
+	Hashtags *[]string `json:"hashtags"`
+	ImageUrl *string   `json:"imageUrl"`
+}
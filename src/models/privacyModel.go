@@ -0,0 +1,43 @@
+package models
+
+const (
+	VisibilityPublic     = "public"
+	VisibilityColleagues = "colleagues"
+	VisibilityTeam       = "team"
+	VisibilityPrivate    = "private"
+)
+
+type PrivacySettings struct {
+	Email        string `bson:"email" json:"email" validate:"omitempty,oneof=public colleagues team private"`
+	Phone        string `bson:"phone" json:"phone" validate:"omitempty,oneof=public colleagues team private"`
+	BirthdayYear string `bson:"birthdayYear" json:"birthdayYear" validate:"omitempty,oneof=public colleagues team private"`
+	Socials      string `bson:"socials" json:"socials" validate:"omitempty,oneof=public colleagues team private"`
+}
+
+var DefaultPrivacySettings = PrivacySettings{
+	Email:        VisibilityPrivate,
+	Phone:        VisibilityColleagues,
+	BirthdayYear: VisibilityColleagues,
+	Socials:      VisibilityColleagues,
+}
+
+func (p *PrivacySettings) WithDefaults() PrivacySettings {
+	settings := DefaultPrivacySettings
+	if p == nil {
+		return settings
+	}
+
+	if p.Email != "" {
+		settings.Email = p.Email
+	}
+	if p.Phone != "" {
+		settings.Phone = p.Phone
+	}
+	if p.BirthdayYear != "" {
+		settings.BirthdayYear = p.BirthdayYear
+	}
+	if p.Socials != "" {
+		settings.Socials = p.Socials
+	}
+	return settings
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Role struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	Name        string             `bson:"name" json:"name" validate:"required,uppercase"`
+	Description string             `bson:"description" json:"description"`
+	Permissions []string           `bson:"permissions" json:"permissions"`
+	RequireMfa  bool               `bson:"requireMfa" json:"requireMfa"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ServiceAccount struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	Uid         string             `bson:"uid" json:"uid"`
+	Name        string             `bson:"name" json:"name" validate:"required"`
+	Description string             `bson:"description" json:"description"`
+	Disabled    bool               `bson:"disabled" json:"disabled"`
+	CreatedBy   string             `bson:"createdBy" json:"createdBy"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+type ApiKey struct {
+	ID               primitive.ObjectID `bson:"_id" json:"id"`
+	ServiceAccountId string             `bson:"serviceAccountId" json:"serviceAccountId"`
+	Name             string             `bson:"name" json:"name" validate:"required"`
+	Prefix           string             `bson:"prefix" json:"prefix"`
+	KeyHash          string             `bson:"keyHash" json:"-"`
+	Scopes           []string           `bson:"scopes" json:"scopes"`
+	CreatedBy        string             `bson:"createdBy" json:"createdBy"`
+	CreatedAt        time.Time          `bson:"createdAt" json:"createdAt"`
+	LastUsedAt       *time.Time         `bson:"lastUsedAt,omitempty" json:"lastUsedAt,omitempty"`
+	ExpiresAt        *time.Time         `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+	RevokedAt        *time.Time         `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+}
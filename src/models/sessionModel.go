@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Session struct {
+	ID             primitive.ObjectID `bson:"_id" json:"id"`
+	UserId         string             `bson:"userId" json:"userId"`
+	ImpersonatorId string             `bson:"impersonatorId,omitempty" json:"impersonatorId,omitempty"`
+	TokenId        string             `bson:"tokenId" json:"-"`
+	UserAgent      string             `bson:"userAgent" json:"userAgent"`
+	IP             string             `bson:"ip" json:"ip"`
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	LastUsedAt     time.Time          `bson:"lastUsedAt" json:"lastUsedAt"`
+	ExpiresAt      time.Time          `bson:"expiresAt" json:"expiresAt"`
+	Revoked        bool               `bson:"revoked" json:"revoked"`
+	RevokedAt      *time.Time         `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+	RevokedReason  string             `bson:"revokedReason,omitempty" json:"revokedReason,omitempty"`
+}
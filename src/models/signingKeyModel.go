@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SigningKey struct {
+	ID                  primitive.ObjectID `bson:"_id" json:"id"`
+	Kid                 string             `bson:"kid" json:"kid"`
+	Algorithm           string             `bson:"algorithm" json:"algorithm"`
+	PrivateKey          string             `bson:"privateKey,omitempty" json:"-"`
+	EncryptedPrivateKey string             `bson:"encryptedPrivateKey,omitempty" json:"-"`
+	PublicKey           string             `bson:"publicKey" json:"publicKey"`
+	Status              string             `bson:"status" json:"status"`
+	CreatedAt           time.Time          `bson:"createdAt" json:"createdAt"`
+	RetiredAt           *time.Time         `bson:"retiredAt,omitempty" json:"retiredAt,omitempty"`
+	ExpiresAt           *time.Time         `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+}
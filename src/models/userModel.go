@@ -11,17 +11,36 @@ type User struct {
 	Name              *string            `bson:"name" json:"name" validate:"required"`
 	Email             *string            `bson:"email" json:"email" validate:"required"`
 	Password          *string            `bson:"password" json:"password" validate:"required"`
+	PasswordHistory   []string           `bson:"passwordHistory,omitempty" json:"-"`
 	UserType          *string            `bson:"userType" json:"userType" validate:"required"`
 	Uid               string             `bson:"uid" json:"uid"`
 	ProfilePictureUrl string             `bson:"profilePictureUrl" json:"profilePictureUrl"`
 	PhoneNumber       *string            `bson:"phoneNumber" json:"phoneNumber"`
 	Role              *string            `bson:"role" json:"role"`
+	DepartmentId      string             `bson:"departmentId,omitempty" json:"departmentId,omitempty"`
+	ManagerId         string             `bson:"managerId,omitempty" json:"managerId,omitempty"`
 	EntryDate         time.Time          `bson:"entryDate" json:"entryDate"`
-	Birthday          time.Time          `bson:"birthday" json:"birthday"`
+	Birthday          time.Time          `bson:"birthday,omitempty" json:"birthday"`
 	LinkedinURL       *string            `bson:"linkedinUrl" json:"linkedinUrl"`
 	FacebookURL       *string            `bson:"facebookUrl" json:"facebookUrl"`
 	InstagramURL      *string            `bson:"instagramUrl" json:"instagramUrl"`
 	PTotal            *int               `bson:"pTotal" json:"pTotal"`
 	PSpent            *int               `bson:"pSpent" json:"pSpent"`
 	PCurrent          *int               `bson:"pCurrent" json:"pCurrent"`
+	Privacy           *PrivacySettings   `bson:"privacy,omitempty" json:"privacy,omitempty"`
+	Active            *bool              `bson:"active,omitempty" json:"active"`
+	Pending           bool               `bson:"pending,omitempty" json:"pending,omitempty"`
+	DeactivatedAt     *time.Time         `bson:"deactivatedAt,omitempty" json:"deactivatedAt,omitempty"`
+	DeactivatedBy     string             `bson:"deactivatedBy,omitempty" json:"deactivatedBy,omitempty"`
+	OidcSubject       string             `bson:"oidcSubject,omitempty" json:"-"`
+	LdapDN            string             `bson:"ldapDn,omitempty" json:"-"`
+	MfaEnabled        bool               `bson:"mfaEnabled" json:"mfaEnabled"`
+	MfaSecret         string             `bson:"mfaSecret,omitempty" json:"-"`
+	MfaPendingSecret  string             `bson:"mfaPendingSecret,omitempty" json:"-"`
+	MfaLastStep       int64              `bson:"mfaLastStep,omitempty" json:"-"`
+	MfaRecoveryCodes  []string           `bson:"mfaRecoveryCodes,omitempty" json:"-"`
+}
+
+func (u User) IsActive() bool {
+	return u.Active == nil || *u.Active
 }
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+type UserProfileUpdate struct {
+	ProfilePictureUrl *string    `json:"profilePictureUrl" validate:"omitempty,url|eq="`
+	PhoneNumber       *string    `json:"phoneNumber" validate:"omitempty,phone|eq="`
+	Birthday          *time.Time `json:"birthday"`
+	LinkedinURL       *string    `json:"linkedinUrl" validate:"omitempty,url|eq="`
+	FacebookURL       *string    `json:"facebookUrl" validate:"omitempty,url|eq="`
+	InstagramURL      *string    `json:"instagramUrl" validate:"omitempty,url|eq="`
+}
+
+type UserAdminUpdate struct {
+	UserProfileUpdate
+	Name         *string    `json:"name" validate:"omitempty,min=1,max=120"`
+	Email        *string    `json:"email" validate:"omitempty,email"`
+	Role         *string    `json:"role" validate:"omitempty,max=120"`
+	EntryDate    *time.Time `json:"entryDate"`
+	UserType     *string    `json:"userType" validate:"omitempty,uppercase"`
+	DepartmentId *string    `json:"departmentId" validate:"omitempty,mongodb|eq="`
+	ManagerId    *string    `json:"managerId" validate:"omitempty,mongodb|eq="`
+}
+
+var UserProfileFields = []string{"profilePictureUrl", "phoneNumber", "birthday", "linkedinUrl", "facebookUrl", "instagramUrl"}
+var UserAdminFields = []string{"name", "email", "role", "entryDate", "departmentId", "managerId"}
+var UserRoleFields = []string{"userType"}
@@ -0,0 +1,344 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	models "github.com/Nooksd/go-server/src/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrDisabled = errors.New("login via SSO não configurado")
+var ErrInvalidToken = errors.New("token de identidade inválido")
+
+var defaultHttpClient = &http.Client{Timeout: 10 * time.Second}
+
+type Provider struct {
+	Issuer            string
+	ClientId          string
+	ClientSecret      string
+	RedirectURL       string
+	Scopes            string
+	HttpClient        *http.Client
+	MetadataDuration  time.Duration
+	KeyReloadInterval time.Duration
+
+	mu       sync.Mutex
+	metadata *metadata
+	keys     map[string]interface{}
+	loadedAt time.Time
+	keysAt   time.Time
+}
+
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func PKCEChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func (p *Provider) Enabled() bool {
+	return p.Issuer != "" && p.ClientId != "" && p.RedirectURL != ""
+}
+
+func (p *Provider) httpClient() *http.Client {
+	if p.HttpClient != nil {
+		return p.HttpClient
+	}
+	return defaultHttpClient
+}
+
+func (p *Provider) fetchJSON(ctx context.Context, endpoint string, target interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := p.httpClient().Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("resposta inesperada de %s: %d", endpoint, response.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(response.Body, 1<<20)).Decode(target)
+}
+
+func (p *Provider) discovery(ctx context.Context) (*metadata, error) {
+	if !p.Enabled() {
+		return nil, ErrDisabled
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil && time.Since(p.loadedAt) < p.MetadataDuration {
+		return p.metadata, nil
+	}
+
+	var discovered metadata
+	if err := p.fetchJSON(ctx, p.Issuer+"/.well-known/openid-configuration", &discovered); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSuffix(discovered.Issuer, "/") != p.Issuer {
+		return nil, fmt.Errorf("issuer divergente na descoberta OIDC: %s", discovered.Issuer)
+	}
+	if discovered.AuthorizationEndpoint == "" || discovered.TokenEndpoint == "" || discovered.JwksURI == "" {
+		return nil, errors.New("metadados OIDC incompletos")
+	}
+
+	p.metadata = &discovered
+	p.loadedAt = time.Now()
+	p.keysAt = time.Time{}
+	return &discovered, nil
+}
+
+func (p *Provider) AuthorizationURL(ctx context.Context, state string, nonce string, verifier string) (string, error) {
+	discovered, err := p.discovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.ClientId)
+	query.Set("redirect_uri", p.RedirectURL)
+	query.Set("scope", p.Scopes)
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", PKCEChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovered.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovered.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+func (p *Provider) ExchangeCode(ctx context.Context, code string, verifier string) (string, error) {
+	discovered, err := p.discovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.RedirectURL)
+	form.Set("client_id", p.ClientId)
+	form.Set("code_verifier", verifier)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, discovered.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	if p.ClientSecret != "" {
+		request.SetBasicAuth(url.QueryEscape(p.ClientId), url.QueryEscape(p.ClientSecret))
+	}
+
+	response, err := p.httpClient().Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	var tokenResponse struct {
+		IdToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(response.Body, 1<<20)).Decode(&tokenResponse); err != nil {
+		return "", err
+	}
+
+	if response.StatusCode != http.StatusOK || tokenResponse.IdToken == "" {
+		return "", fmt.Errorf("falha na troca do código OIDC: %s %s", tokenResponse.Error, tokenResponse.ErrorDescription)
+	}
+
+	return tokenResponse.IdToken, nil
+}
+
+func decodeBase64Int(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func parseJWK(key jwk) (interface{}, error) {
+	switch key.Kty {
+	case "RSA":
+		n, err := decodeBase64Int(key.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64Int(key.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch key.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("curva não suportada: %s", key.Crv)
+		}
+		x, err := decodeBase64Int(key.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64Int(key.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if key.Crv != "Ed25519" {
+			return nil, fmt.Errorf("curva não suportada: %s", key.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("chave Ed25519 inválida")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("tipo de chave não suportado: %s", key.Kty)
+}
+
+func (p *Provider) verificationKey(ctx context.Context, kid string) (interface{}, error) {
+	discovered, err := p.discovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok && time.Since(p.keysAt) < p.MetadataDuration {
+		return key, nil
+	}
+
+	if time.Since(p.keysAt) < p.KeyReloadInterval {
+		return nil, ErrInvalidToken
+	}
+
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+	p.keysAt = time.Now()
+	if err := p.fetchJSON(ctx, discovered.JwksURI, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]interface{}{}
+	for _, entry := range jwks.Keys {
+		if entry.Use != "" && entry.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(entry)
+		if err != nil {
+			continue
+		}
+		keys[entry.Kid] = key
+	}
+	p.keys = keys
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return key, nil
+}
+
+func (p *Provider) VerifyIdToken(ctx context.Context, idToken string, nonce string) (models.OIDCClaims, error) {
+	claims := jwt.MapClaims{}
+
+	token, err := jwt.ParseWithClaims(
+		idToken,
+		claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.verificationKey(ctx, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithIssuer(p.Issuer),
+		jwt.WithAudience(p.ClientId),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil || !token.Valid {
+		return models.OIDCClaims{}, ErrInvalidToken
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce == "" || tokenNonce != nonce {
+		return models.OIDCClaims{}, ErrInvalidToken
+	}
+
+	result := models.OIDCClaims{}
+	result.Subject, _ = claims["sub"].(string)
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+	result.Picture, _ = claims["picture"].(string)
+
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = verified == "true"
+	}
+
+	result.Email = strings.ToLower(strings.TrimSpace(result.Email))
+
+	if result.Subject == "" || result.Email == "" {
+		return models.OIDCClaims{}, errors.New("token de identidade sem sub ou email")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,274 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type testIssuer struct {
+	url      string
+	rsaKey   *rsa.PrivateKey
+	ecKey    *ecdsa.PrivateKey
+	jwksHits atomic.Int32
+}
+
+func encodeInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := &testIssuer{rsaKey: rsaKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.url,
+			"authorization_endpoint": issuer.url + "/authorize",
+			"token_endpoint":         issuer.url + "/token",
+			"jwks_uri":               issuer.url + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		issuer.jwksHits.Add(1)
+		json.NewEncoder(w).Encode(map[string][]jwk{"keys": {
+			{Kid: "rsa-1", Kty: "RSA", Use: "sig", N: encodeInt(rsaKey.N), E: encodeInt(big.NewInt(int64(rsaKey.E)))},
+			{Kid: "ec-1", Kty: "EC", Crv: "P-256", X: encodeInt(ecKey.X), Y: encodeInt(ecKey.Y)},
+			{Kid: "enc-1", Kty: "RSA", Use: "enc", N: encodeInt(rsaKey.N), E: encodeInt(big.NewInt(int64(rsaKey.E)))},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "valid-code" || r.Form.Get("code_verifier") != "verifier" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": "signed-token"})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	issuer.url = server.URL
+	return issuer
+}
+
+func (issuer *testIssuer) provider() *Provider {
+	return &Provider{
+		Issuer:           issuer.url,
+		ClientId:         "client-id",
+		RedirectURL:      "https://app.example.com/auth/oidc/callback",
+		Scopes:           "openid email profile",
+		MetadataDuration: time.Hour,
+	}
+}
+
+func (issuer *testIssuer) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            issuer.url,
+		"aud":            "client-id",
+		"sub":            "subject-1",
+		"email":          " Maria@Example.com ",
+		"email_verified": true,
+		"name":           "Maria",
+		"nonce":          "nonce-1",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+	}
+}
+
+func (issuer *testIssuer) sign(t *testing.T, method jwt.SigningMethod, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+
+	var key interface{} = issuer.rsaKey
+	if _, ok := method.(*jwt.SigningMethodECDSA); ok {
+		key = issuer.ecKey
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestPKCEChallenge(t *testing.T) {
+	// RFC 7636, apêndice B.
+	challenge := PKCEChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if challenge != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Fatalf("desafio PKCE inesperado: %s", challenge)
+	}
+}
+
+func TestAuthorizationURL(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	authorizationUrl, err := issuer.provider().AuthorizationURL(context.Background(), "state-1", "nonce-1", "verifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := url.Parse(authorizationUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+
+	if parsed.Path != "/authorize" {
+		t.Errorf("endpoint inesperado: %s", parsed.Path)
+	}
+	if query.Get("state") != "state-1" || query.Get("nonce") != "nonce-1" {
+		t.Errorf("state ou nonce ausentes: %s", parsed.RawQuery)
+	}
+	if query.Get("code_challenge") != PKCEChallenge("verifier") || query.Get("code_challenge_method") != "S256" {
+		t.Errorf("desafio PKCE ausente: %s", parsed.RawQuery)
+	}
+}
+
+func TestExchangeCode(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider()
+
+	idToken, err := provider.ExchangeCode(context.Background(), "valid-code", "verifier")
+	if err != nil || idToken != "signed-token" {
+		t.Fatalf("troca de código falhou: %q %v", idToken, err)
+	}
+
+	if _, err := provider.ExchangeCode(context.Background(), "valid-code", "other"); err == nil {
+		t.Fatal("troca com verificador errado deveria falhar")
+	}
+}
+
+func TestVerifyIdToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider()
+
+	claims, err := provider.VerifyIdToken(context.Background(), issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", issuer.claims()), "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "subject-1" || claims.Email != "maria@example.com" || !claims.EmailVerified || claims.Name != "Maria" {
+		t.Fatalf("claims inesperadas: %+v", claims)
+	}
+
+	claims, err = provider.VerifyIdToken(context.Background(), issuer.sign(t, jwt.SigningMethodES256, "ec-1", issuer.claims()), "nonce-1")
+	if err != nil || claims.Subject != "subject-1" {
+		t.Fatalf("token ES256 rejeitado: %+v %v", claims, err)
+	}
+}
+
+func TestVerifyIdTokenRejects(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider()
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]func() string{
+		"nonce divergente": func() string {
+			return issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", issuer.claims())
+		},
+		"issuer divergente": func() string {
+			claims := issuer.claims()
+			claims["iss"] = "https://outro.example.com"
+			return issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", claims)
+		},
+		"audiência divergente": func() string {
+			claims := issuer.claims()
+			claims["aud"] = "outro-cliente"
+			return issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", claims)
+		},
+		"expirado": func() string {
+			claims := issuer.claims()
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", claims)
+		},
+		"sem expiração": func() string {
+			claims := issuer.claims()
+			delete(claims, "exp")
+			return issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", claims)
+		},
+		"kid desconhecido": func() string {
+			return issuer.sign(t, jwt.SigningMethodRS256, "rsa-2", issuer.claims())
+		},
+		"chave de cifragem": func() string {
+			return issuer.sign(t, jwt.SigningMethodRS256, "enc-1", issuer.claims())
+		},
+		"assinatura inválida": func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, issuer.claims())
+			token.Header["kid"] = "rsa-1"
+			signed, _ := token.SignedString(other)
+			return signed
+		},
+		"algoritmo simétrico": func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, issuer.claims())
+			token.Header["kid"] = "rsa-1"
+			signed, _ := token.SignedString([]byte("segredo"))
+			return signed
+		},
+	}
+
+	for name, build := range cases {
+		t.Run(name, func(t *testing.T) {
+			nonce := "nonce-1"
+			if name == "nonce divergente" {
+				nonce = "nonce-2"
+			}
+			if _, err := provider.VerifyIdToken(context.Background(), build(), nonce); err != ErrInvalidToken {
+				t.Fatalf("esperado ErrInvalidToken, obtido %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyIdTokenThrottlesKeyReload(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider()
+	provider.KeyReloadInterval = time.Minute
+
+	for i := 0; i < 3; i++ {
+		provider.VerifyIdToken(context.Background(), issuer.sign(t, jwt.SigningMethodRS256, "rsa-2", issuer.claims()), "nonce-1")
+	}
+	if hits := issuer.jwksHits.Load(); hits != 1 {
+		t.Fatalf("JWKS buscado %d vezes, esperado 1", hits)
+	}
+}
+
+func TestDisabledProvider(t *testing.T) {
+	provider := &Provider{}
+	if _, err := provider.VerifyIdToken(context.Background(), "token", "nonce"); err != ErrInvalidToken {
+		t.Fatalf("esperado ErrInvalidToken, obtido %v", err)
+	}
+	if _, err := provider.AuthorizationURL(context.Background(), "state", "nonce", "verifier"); err != ErrDisabled {
+		t.Fatalf("esperado ErrDisabled, obtido %v", err)
+	}
+}
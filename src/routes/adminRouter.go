@@ -0,0 +1,40 @@
+package routes
+
+import (
+	controller "github.com/Nooksd/go-server/src/controllers"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	middleware "github.com/Nooksd/go-server/src/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func AdminRoutes(router *gin.Engine) {
+	admin := router.Group("/admin")
+
+	admin.GET("/permissions", middleware.RequirePermission(helper.PermRoleManage), controller.GetPermissions())
+	admin.GET("/roles", middleware.RequirePermission(helper.PermRoleManage), controller.GetRoles())
+	admin.POST("/roles", middleware.RequirePermission(helper.PermRoleManage), controller.CreateRole())
+	admin.PUT("/roles/:name", middleware.RequirePermission(helper.PermRoleManage), controller.UpdateRole())
+	admin.DELETE("/roles/:name", middleware.RequirePermission(helper.PermRoleManage), controller.DeleteRole())
+
+	admin.POST("/users/:userId/invite", middleware.RequirePermission(helper.PermUserCreate), controller.ResendInvitation())
+	admin.POST("/users/:userId/unlock", middleware.RequirePermission(helper.PermUserUnlock), controller.UnlockUser())
+	admin.PUT("/users/:userId/deactivate", middleware.RequirePermission(helper.PermUserDeactivate), controller.DeactivateUser())
+	admin.PUT("/users/:userId/reactivate", middleware.RequirePermission(helper.PermUserDeactivate), controller.ReactivateUser())
+	admin.POST("/impersonate/:userId", middleware.RequirePermission(helper.PermUserImpersonate), controller.StartImpersonation())
+
+	admin.POST("/ldap/sync", middleware.RequirePermission(helper.PermDirectorySync), controller.SyncLDAP())
+	admin.GET("/ldap/reports", middleware.RequirePermission(helper.PermDirectorySync), controller.GetLDAPSyncReports())
+	admin.GET("/ldap/reports/:reportId", middleware.RequirePermission(helper.PermDirectorySync), controller.GetLDAPSyncReport())
+
+	admin.GET("/audit", middleware.RequirePermission(helper.PermAuditRead), controller.GetAuditLog())
+
+	admin.GET("/settings/anniversaries", middleware.RequirePermission(helper.PermSettingsManage), controller.GetAnniversarySettings())
+	admin.PUT("/settings/anniversaries", middleware.RequirePermission(helper.PermSettingsManage), controller.UpdateAnniversarySettings())
+
+	admin.POST("/service-accounts", middleware.RequirePermission(helper.PermServiceAccountManage), controller.CreateServiceAccount())
+	admin.GET("/service-accounts", middleware.RequirePermission(helper.PermServiceAccountManage), controller.GetServiceAccounts())
+	admin.DELETE("/service-accounts/:accountId", middleware.RequirePermission(helper.PermServiceAccountManage), controller.DisableServiceAccount())
+	admin.POST("/service-accounts/:accountId/keys", middleware.RequirePermission(helper.PermServiceAccountManage), controller.CreateApiKey())
+	admin.GET("/service-accounts/:accountId/keys", middleware.RequirePermission(helper.PermServiceAccountManage), controller.GetApiKeys())
+	admin.DELETE("/service-accounts/:accountId/keys/:keyId", middleware.RequirePermission(helper.PermServiceAccountManage), controller.RevokeApiKey())
+}
@@ -2,10 +2,24 @@ package routes
 
 import (
 	controller "github.com/Nooksd/go-server/src/controllers"
+	middleware "github.com/Nooksd/go-server/src/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
 func AuthRoutes(router *gin.Engine) {
 	router.POST("/auth/login", controller.LoginUser())
 	router.GET("/auth/refresh-token", controller.RefreshToken())
+	router.POST("/auth/forgot-password", controller.ForgotPassword())
+	router.POST("/auth/reset-password", controller.ResetPassword())
+	router.GET("/auth/accept-invite", controller.GetInvitation())
+	router.POST("/auth/accept-invite", controller.AcceptInvite())
+	router.POST("/auth/mfa/setup", controller.SetupMfaLogin())
+	router.POST("/auth/mfa/verify", controller.VerifyMfaLogin())
+	router.GET("/auth/oidc/start", controller.StartOIDCLogin())
+	router.GET("/auth/oidc/callback", controller.OIDCCallback())
+
+	router.POST("/auth/logout", middleware.Authenticate(), controller.Logout())
+	router.POST("/auth/logout-all", middleware.Authenticate(), controller.LogoutAll())
+	router.GET("/auth/sessions", middleware.Authenticate(), controller.GetSessions())
+	router.DELETE("/auth/sessions/:sessionId", middleware.Authenticate(), controller.DeleteSession())
 }
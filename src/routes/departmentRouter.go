@@ -0,0 +1,17 @@
+package routes
+
+import (
+	controller "github.com/Nooksd/go-server/src/controllers"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	middleware "github.com/Nooksd/go-server/src/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func DepartmentRoutes(router *gin.Engine) {
+	router.GET("/departments", controller.GetDepartments())
+	router.GET("/departments/:departmentId", controller.GetDepartment())
+	router.POST("/departments", middleware.RequirePermission(helper.PermDepartmentManage), controller.CreateDepartment())
+	router.PUT("/departments/:departmentId", middleware.RequirePermission(helper.PermDepartmentManage), controller.UpdateDepartment())
+	router.DELETE("/departments/:departmentId", middleware.RequirePermission(helper.PermDepartmentManage), controller.DeleteDepartment())
+	router.GET("/org/chart", controller.GetOrgChart())
+}
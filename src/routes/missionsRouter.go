@@ -2,14 +2,16 @@ package routes
 
 import (
 	controller "github.com/Nooksd/go-server/src/controllers"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	middleware "github.com/Nooksd/go-server/src/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
 func MissionsRoutes(router *gin.Engine) {
-	router.POST("/mission/create", controller.CreateMission())
+	router.POST("/mission/create", middleware.RequirePermission(helper.PermMissionCreate), controller.CreateMission())
 	router.GET("/mission/get-all", controller.GetMissions())
 	router.GET("/mission/get-current", controller.GetCurrentMissions())
-	router.PUT("/mission/complete/:missionId/:userId", controller.CompleteMission())
+	router.PUT("/mission/complete/:missionId/:userId", middleware.RequirePermission(helper.PermMissionComplete), controller.CompleteMission())
 	router.GET("/mission/verify-completion/:missionId", controller.VerifyCompletion())
-	router.DELETE("/mission/delete/:missionId", controller.DeleteMission())
+	router.DELETE("/mission/delete/:missionId", middleware.RequirePermission(helper.PermMissionDelete), controller.DeleteMission())
 }
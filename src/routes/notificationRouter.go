@@ -1,15 +1,21 @@
 package routes
 
 import (
+	"net/http"
+
 	"github.com/Nooksd/go-server/src/controllers"
+	"github.com/Nooksd/go-server/src/helpers"
+	"github.com/Nooksd/go-server/src/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
 func NotificationRoutes(router *gin.Engine) {
-	router.POST("/notification/create", controllers.CreateNotification())
+	middlewares.AllowAPIKey(http.MethodPost, "/notification/create", helpers.PermNotificationBroadcast)
+
+	router.POST("/notification/create", middlewares.RequirePermission(helpers.PermNotificationBroadcast), controllers.CreateNotification())
 	router.GET("/notification/get-all", controllers.GetNotifications())
 	router.PUT("/notification/read/:notificationId", controllers.ReadNotification())
-	router.DELETE("/notification/delete/:notificationId", controllers.DeleteNotification())
+	router.DELETE("/notification/delete/:notificationId", middlewares.RequirePermission(helpers.PermNotificationDelete), controllers.DeleteNotification())
 	router.POST("/notification/register-token", controllers.SaveDeviceToken())
 
 }
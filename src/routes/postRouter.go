@@ -1,14 +1,24 @@
 package routes
 
 import (
+	"net/http"
+
 	controller "github.com/Nooksd/go-server/src/controllers"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	middleware "github.com/Nooksd/go-server/src/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
 func PostRoutes(router *gin.Engine) {
+	middleware.AllowAPIKey(http.MethodPost, "/post/create", helper.PermPostCreate)
+	middleware.AllowAPIKey(http.MethodPost, "/post/image/upload", helper.PermPostCreate)
+
 	router.POST("/post/create", controller.UploadPost())
 	router.GET("/post/get/:postId", controller.GetPost())
 	router.GET("/post/get", controller.GetPosts())
+	router.PUT("/post/edit/:postId", controller.EditPost())
+	router.GET("/post/revisions/:postId", middleware.RequirePermission(helper.PermPostModerate), controller.GetPostRevisions())
+	router.PUT("/post/revisions/:postId/:revisionId/restore", middleware.RequirePermission(helper.PermPostModerate), controller.RestorePostRevision())
 
 	router.POST("/post/like/:postId", controller.LikePost())
 	router.POST("/post/dislike/:postId", controller.DislikePost())
@@ -18,4 +28,7 @@ func PostRoutes(router *gin.Engine) {
 	router.POST("/post/image/upload", controller.UploadImage())
 
 	router.DELETE("/post/delete/:postId", controller.DeletePost())
+
+	router.PUT("/post/pin/:postId", middleware.RequirePermission(helper.PermPostModerate), controller.PinPost())
+	router.DELETE("/post/pin/:postId", middleware.RequirePermission(helper.PermPostModerate), controller.UnpinPost())
 }
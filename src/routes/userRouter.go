@@ -1,18 +1,34 @@
 package routes
 
 import (
+	"net/http"
+
 	controller "github.com/Nooksd/go-server/src/controllers"
+	helper "github.com/Nooksd/go-server/src/helpers"
 	middleware "github.com/Nooksd/go-server/src/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
 func UserRoutes(router *gin.Engine) {
+	middleware.AllowAPIKey(http.MethodPost, "/user/create", helper.PermUserCreate)
+	middleware.AllowAPIKey(http.MethodPost, "/user/import", helper.PermUserCreate)
+
 	router.Use(middleware.Authenticate())
-	router.POST("/user/create", controller.CreateUser())
+	router.POST("/user/create", middleware.RequirePermission(helper.PermUserCreate), controller.CreateUser())
+	router.POST("/user/import", middleware.RequirePermission(helper.PermUserCreate, helper.PermUserUpdate), controller.ImportUsers())
 	router.GET("/users", controller.SearchUsers())
 	router.POST("/avatar/upload/:userId", controller.UploadAvatar())
 	router.GET("/users/birthdays", controller.GetBirthdays())
+	router.GET("/users/anniversaries", controller.GetAnniversaries())
 	router.GET("/users/:userId", controller.GetOneUser())
+	router.GET("/users/:userId/reports", controller.GetUserReports())
 	router.GET("/users/get-current-user", controller.GetCurrentUser())
 	router.PUT("/users/update/:userId", controller.UpdateOneUser())
+	router.PUT("/users/me/password", controller.ChangePassword())
+	router.GET("/users/me/privacy", controller.GetPrivacySettings())
+	router.PUT("/users/me/privacy", controller.UpdatePrivacySettings())
+	router.POST("/users/me/mfa/setup", controller.SetupMfa())
+	router.POST("/users/me/mfa/enable", controller.EnableMfa())
+	router.POST("/users/me/mfa/disable", controller.DisableMfa())
+	router.POST("/users/me/mfa/recovery-codes", controller.RegenerateRecoveryCodes())
 }
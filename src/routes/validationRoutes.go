@@ -2,12 +2,14 @@ package routes
 
 import (
 	controller "github.com/Nooksd/go-server/src/controllers"
+	helper "github.com/Nooksd/go-server/src/helpers"
+	middleware "github.com/Nooksd/go-server/src/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
 func ValidationRoutes(router *gin.Engine) {
 	router.POST("/validation/create", controller.CreateValidation())
-	router.PUT("/validation/accept/:validationId", controller.AcceptValidation())
-	router.PUT("/validation/reject/:validationId", controller.RejectValidation())
+	router.PUT("/validation/accept/:validationId", middleware.RequirePermission(helper.PermValidationReview), controller.AcceptValidation())
+	router.PUT("/validation/reject/:validationId", middleware.RequirePermission(helper.PermValidationReview), controller.RejectValidation())
 	router.GET("/validation/get-pending", controller.GetPendingValidations())
 }
@@ -0,0 +1,10 @@
+package routes
+
+import (
+	controller "github.com/Nooksd/go-server/src/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+func WellKnownRoutes(router *gin.Engine) {
+	router.GET("/.well-known/jwks.json", controller.GetJWKS())
+}